@@ -0,0 +1,228 @@
+package arbol_intervalos
+
+import "iter"
+
+// Arbol AVL ordenado por (inicio, fin), donde cada nodo guarda ademas el mayor fin de su subarbol. Eso permite
+// descartar subarboles enteros durante una consulta, que cuesta O(log n + k) siendo k la cantidad de solapados.
+
+type nodoIntervalo[K any, V any] struct {
+	izquierdo *nodoIntervalo[K, V]
+	derecho   *nodoIntervalo[K, V]
+	inicio    K
+	fin       K
+	maxFin    K
+	altura    int
+	dato      V
+}
+
+type arbolAVL[K any, V any] struct {
+	raiz     *nodoIntervalo[K, V]
+	cantidad int
+	cmp      func(K, K) int
+}
+
+// PRE:
+// POST: retorna el mensaje de error correspondiente al tipo proporcionado
+func mensajePanic(tipo string) string {
+	if tipo == "intervalo" {
+		return "Intervalo invalido"
+	}
+	if tipo == "arbol" {
+		return "El intervalo no pertenece al arbol"
+	}
+	return "Error desconocido"
+}
+
+func CrearArbolIntervalos[K any, V any](funcion_cmp func(K, K) int) ArbolIntervalos[K, V] {
+	return &arbolAVL[K, V]{cmp: funcion_cmp}
+}
+
+// PRE:
+// POST: retorna la altura del nodo, 0 si es nil
+func altura[K any, V any](nodo *nodoIntervalo[K, V]) int {
+	if nodo == nil {
+		return 0
+	}
+	return nodo.altura
+}
+
+// PRE: nodo no debe de ser nil y sus hijos deben de estar actualizados
+// POST: recalcula la altura y el mayor fin del subarbol del nodo
+func (a *arbolAVL[K, V]) actualizar(nodo *nodoIntervalo[K, V]) {
+	nodo.altura = 1 + max(altura(nodo.izquierdo), altura(nodo.derecho))
+	nodo.maxFin = nodo.fin
+	for _, hijo := range [2]*nodoIntervalo[K, V]{nodo.izquierdo, nodo.derecho} {
+		if hijo != nil && a.cmp(hijo.maxFin, nodo.maxFin) > 0 {
+			nodo.maxFin = hijo.maxFin
+		}
+	}
+}
+
+// PRE: nodo y su hijo izquierdo no deben de ser nil
+// POST: rota el subarbol a derecha y retorna la nueva raiz
+func (a *arbolAVL[K, V]) rotarDerecha(nodo *nodoIntervalo[K, V]) *nodoIntervalo[K, V] {
+	nuevaRaiz := nodo.izquierdo
+	nodo.izquierdo = nuevaRaiz.derecho
+	nuevaRaiz.derecho = nodo
+	a.actualizar(nodo)
+	a.actualizar(nuevaRaiz)
+	return nuevaRaiz
+}
+
+// PRE: nodo y su hijo derecho no deben de ser nil
+// POST: rota el subarbol a izquierda y retorna la nueva raiz
+func (a *arbolAVL[K, V]) rotarIzquierda(nodo *nodoIntervalo[K, V]) *nodoIntervalo[K, V] {
+	nuevaRaiz := nodo.derecho
+	nodo.derecho = nuevaRaiz.izquierdo
+	nuevaRaiz.izquierdo = nodo
+	a.actualizar(nodo)
+	a.actualizar(nuevaRaiz)
+	return nuevaRaiz
+}
+
+// PRE: nodo no debe de ser nil y sus subarboles deben de estar balanceados
+// POST: actualiza el nodo, lo rota si quedo desbalanceado y retorna la raiz del subarbol
+func (a *arbolAVL[K, V]) balancear(nodo *nodoIntervalo[K, V]) *nodoIntervalo[K, V] {
+	a.actualizar(nodo)
+	factor := altura(nodo.izquierdo) - altura(nodo.derecho)
+	if factor > 1 {
+		if altura(nodo.izquierdo.izquierdo) < altura(nodo.izquierdo.derecho) {
+			nodo.izquierdo = a.rotarIzquierda(nodo.izquierdo)
+		}
+		return a.rotarDerecha(nodo)
+	}
+	if factor < -1 {
+		if altura(nodo.derecho.derecho) < altura(nodo.derecho.izquierdo) {
+			nodo.derecho = a.rotarDerecha(nodo.derecho)
+		}
+		return a.rotarIzquierda(nodo)
+	}
+	return nodo
+}
+
+// PRE: nodo no debe de ser nil
+// POST: compara el intervalo [inicio, fin] con el del nodo, primero por inicio y luego por fin
+func (a *arbolAVL[K, V]) compararIntervalo(inicio, fin K, nodo *nodoIntervalo[K, V]) int {
+	if comparacion := a.cmp(inicio, nodo.inicio); comparacion != 0 {
+		return comparacion
+	}
+	return a.cmp(fin, nodo.fin)
+}
+
+// PRE:
+// POST: inserta el intervalo en el subarbol y retorna su nueva raiz. Los intervalos repetidos van a la derecha
+func (a *arbolAVL[K, V]) insertarNodo(nodo *nodoIntervalo[K, V], inicio, fin K, dato V) *nodoIntervalo[K, V] {
+	if nodo == nil {
+		return &nodoIntervalo[K, V]{inicio: inicio, fin: fin, maxFin: fin, altura: 1, dato: dato}
+	}
+	if a.compararIntervalo(inicio, fin, nodo) < 0 {
+		nodo.izquierdo = a.insertarNodo(nodo.izquierdo, inicio, fin, dato)
+	} else {
+		nodo.derecho = a.insertarNodo(nodo.derecho, inicio, fin, dato)
+	}
+	return a.balancear(nodo)
+}
+
+func (a *arbolAVL[K, V]) Insertar(inicio, fin K, dato V) {
+	if a.cmp(inicio, fin) > 0 {
+		panic(mensajePanic("intervalo"))
+	}
+	a.raiz = a.insertarNodo(a.raiz, inicio, fin, dato)
+	a.cantidad++
+}
+
+// PRE: nodo no debe de ser nil
+// POST: quita el menor nodo del subarbol y retorna la nueva raiz y el nodo quitado
+func (a *arbolAVL[K, V]) quitarMinimo(nodo *nodoIntervalo[K, V]) (*nodoIntervalo[K, V], *nodoIntervalo[K, V]) {
+	if nodo.izquierdo == nil {
+		return nodo.derecho, nodo
+	}
+	var minimo *nodoIntervalo[K, V]
+	nodo.izquierdo, minimo = a.quitarMinimo(nodo.izquierdo)
+	return a.balancear(nodo), minimo
+}
+
+// PRE:
+// POST: borra un intervalo con esos extremos del subarbol y retorna su nueva raiz y el dato borrado
+func (a *arbolAVL[K, V]) borrarNodo(nodo *nodoIntervalo[K, V], inicio, fin K) (*nodoIntervalo[K, V], V) {
+	if nodo == nil {
+		panic(mensajePanic("arbol"))
+	}
+	var dato V
+	comparacion := a.compararIntervalo(inicio, fin, nodo)
+	switch {
+	case comparacion < 0:
+		nodo.izquierdo, dato = a.borrarNodo(nodo.izquierdo, inicio, fin)
+	case comparacion > 0:
+		nodo.derecho, dato = a.borrarNodo(nodo.derecho, inicio, fin)
+	default:
+		dato = nodo.dato
+		if nodo.izquierdo == nil {
+			return nodo.derecho, dato
+		}
+		if nodo.derecho == nil {
+			return nodo.izquierdo, dato
+		}
+		var sucesor *nodoIntervalo[K, V]
+		nodo.derecho, sucesor = a.quitarMinimo(nodo.derecho)
+		sucesor.izquierdo, sucesor.derecho = nodo.izquierdo, nodo.derecho
+		nodo = sucesor
+	}
+	return a.balancear(nodo), dato
+}
+
+func (a *arbolAVL[K, V]) Borrar(inicio, fin K) V {
+	var dato V
+	a.raiz, dato = a.borrarNodo(a.raiz, inicio, fin)
+	a.cantidad--
+	return dato
+}
+
+func (a *arbolAVL[K, V]) Cantidad() int {
+	return a.cantidad
+}
+
+// PRE:
+// POST: recorre inorder los intervalos del subarbol que se solapan con [desde, hasta], devolviendo false si se corto la iteracion
+func (a *arbolAVL[K, V]) iterarSolapados(nodo *nodoIntervalo[K, V], desde, hasta K, visitar func(K, K, V) bool) bool {
+	// Si ningun intervalo del subarbol termina despues de desde, ninguno se solapa
+	if nodo == nil || a.cmp(nodo.maxFin, desde) < 0 {
+		return true
+	}
+	if !a.iterarSolapados(nodo.izquierdo, desde, hasta, visitar) {
+		return false
+	}
+	// Si este intervalo empieza despues de hasta, tambien todos los del subarbol derecho
+	if a.cmp(nodo.inicio, hasta) > 0 {
+		return true
+	}
+	if a.cmp(nodo.fin, desde) >= 0 && !visitar(nodo.inicio, nodo.fin, nodo.dato) {
+		return false
+	}
+	return a.iterarSolapados(nodo.derecho, desde, hasta, visitar)
+}
+
+func (a *arbolAVL[K, V]) IterarSolapados(desde, hasta K, visitar func(inicio, fin K, dato V) bool) {
+	a.iterarSolapados(a.raiz, desde, hasta, visitar)
+}
+
+// PRE:
+// POST: recorre inorder el subarbol, devolviendo false si se corto la iteracion
+func (a *arbolAVL[K, V]) iterarNodo(nodo *nodoIntervalo[K, V], visitar func(K, K, V) bool) bool {
+	if nodo == nil {
+		return true
+	}
+	return a.iterarNodo(nodo.izquierdo, visitar) && visitar(nodo.inicio, nodo.fin, nodo.dato) && a.iterarNodo(nodo.derecho, visitar)
+}
+
+func (a *arbolAVL[K, V]) Iterar(visitar func(inicio, fin K, dato V) bool) {
+	a.iterarNodo(a.raiz, visitar)
+}
+
+func (a *arbolAVL[K, V]) Todos() iter.Seq2[Intervalo[K], V] {
+	return func(yield func(Intervalo[K], V) bool) {
+		a.Iterar(func(inicio, fin K, dato V) bool {
+			return yield(Intervalo[K]{Inicio: inicio, Fin: fin}, dato)
+		})
+	}
+}
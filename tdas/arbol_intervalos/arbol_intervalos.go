@@ -0,0 +1,37 @@
+package arbol_intervalos
+
+import "iter"
+
+// ArbolIntervalos guarda intervalos cerrados [inicio, fin] con un dato asociado, y permite encontrar
+// eficientemente todos los que se solapan con un intervalo o un punto dado. Puede haber intervalos repetidos.
+type ArbolIntervalos[K any, V any] interface {
+
+	// Insertar guarda el intervalo [inicio, fin] con su dato. Si inicio es mayor que fin, entra en pánico con un
+	// mensaje 'Intervalo invalido'.
+	Insertar(inicio, fin K, dato V)
+
+	// Borrar borra un intervalo con exactamente esos extremos, devolviendo su dato. Si no hay ninguno, entra en
+	// pánico con un mensaje 'El intervalo no pertenece al arbol'.
+	Borrar(inicio, fin K) V
+
+	// Cantidad devuelve la cantidad de intervalos guardados.
+	Cantidad() int
+
+	// IterarSolapados recorre, ordenados por inicio, los intervalos que tienen algun punto en comun con
+	// [desde, hasta], aplicando visitar mientras devuelva true. Para consultar un punto se usa desde == hasta.
+	IterarSolapados(desde, hasta K, visitar func(inicio, fin K, dato V) bool)
+
+	// Iterar recorre todos los intervalos ordenados por inicio (y a igual inicio, por fin), aplicando visitar
+	// mientras devuelva true.
+	Iterar(visitar func(inicio, fin K, dato V) bool)
+
+	// Todos devuelve un iterador de Go sobre los intervalos, en el mismo orden que Iterar. Los intervalos se
+	// devuelven como pares (intervalo, dato).
+	Todos() iter.Seq2[Intervalo[K], V]
+}
+
+// Intervalo es un intervalo cerrado entre Inicio y Fin
+type Intervalo[K any] struct {
+	Inicio K
+	Fin    K
+}
@@ -0,0 +1,160 @@
+package arbol_intervalos_test
+
+import (
+	"cmp"
+	"math/rand"
+	"sort"
+	TDAIntervalos "tdas/arbol_intervalos"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type intervalo struct {
+	inicio, fin, dato int
+}
+
+// solapados devuelve los intervalos que se solapan con [desde, hasta] en el orden en que los recorre el arbol
+func solapados(arbol TDAIntervalos.ArbolIntervalos[int, int], desde, hasta int) []intervalo {
+	var resultado []intervalo
+	arbol.IterarSolapados(desde, hasta, func(inicio, fin, dato int) bool {
+		resultado = append(resultado, intervalo{inicio, fin, dato})
+		return true
+	})
+	return resultado
+}
+
+func TestArbolVacio(t *testing.T) {
+	arbol := TDAIntervalos.CrearArbolIntervalos[int, string](cmp.Compare[int])
+	require.Equal(t, 0, arbol.Cantidad())
+	arbol.IterarSolapados(0, 100, func(int, int, string) bool {
+		require.Fail(t, "Un arbol vacio no tiene intervalos solapados")
+		return true
+	})
+	require.PanicsWithValue(t, "El intervalo no pertenece al arbol", func() { arbol.Borrar(1, 2) })
+	require.PanicsWithValue(t, "Intervalo invalido", func() { arbol.Insertar(5, 1, "x") })
+}
+
+func TestSolapadosConExtremosInclusive(t *testing.T) {
+	arbol := TDAIntervalos.CrearArbolIntervalos[int, int](cmp.Compare[int])
+	arbol.Insertar(1, 3, 0)
+	arbol.Insertar(5, 8, 1)
+	arbol.Insertar(6, 6, 2)
+	arbol.Insertar(10, 20, 3)
+	require.Equal(t, 4, arbol.Cantidad())
+
+	require.Equal(t, []intervalo{{1, 3, 0}}, solapados(arbol, 3, 3), "Los extremos son inclusive")
+	require.Empty(t, solapados(arbol, 4, 4), "4 no pertenece a ningun intervalo")
+	require.Equal(t, []intervalo{{5, 8, 1}, {6, 6, 2}}, solapados(arbol, 6, 6))
+	require.Equal(t, []intervalo{{5, 8, 1}, {10, 20, 3}}, solapados(arbol, 7, 10))
+	require.Equal(t, []intervalo{{10, 20, 3}}, solapados(arbol, 15, 30))
+	require.Empty(t, solapados(arbol, 21, 30))
+}
+
+func TestIntervalosRepetidosYBorrar(t *testing.T) {
+	arbol := TDAIntervalos.CrearArbolIntervalos[int, string](cmp.Compare[int])
+	arbol.Insertar(1, 5, "a")
+	arbol.Insertar(1, 5, "b")
+	arbol.Insertar(2, 3, "c")
+	require.Equal(t, 3, arbol.Cantidad())
+
+	borrado := arbol.Borrar(1, 5)
+	require.Contains(t, []string{"a", "b"}, borrado)
+	require.Equal(t, 2, arbol.Cantidad())
+	var datos []string
+	for _, dato := range arbol.Todos() {
+		datos = append(datos, dato)
+	}
+	require.Len(t, datos, 2)
+	require.Equal(t, "c", datos[1])
+	require.NotEqual(t, borrado, datos[0])
+
+	require.PanicsWithValue(t, "El intervalo no pertenece al arbol", func() { arbol.Borrar(2, 4) })
+	require.Equal(t, "c", arbol.Borrar(2, 3))
+	arbol.Borrar(1, 5)
+	require.Equal(t, 0, arbol.Cantidad())
+}
+
+func TestIterarEnOrdenYCorte(t *testing.T) {
+	arbol := TDAIntervalos.CrearArbolIntervalos[int, int](cmp.Compare[int])
+	for i := 100; i > 0; i-- {
+		arbol.Insertar(i, i+10, i)
+	}
+	anterior := 0
+	arbol.Iterar(func(inicio, fin, dato int) bool {
+		require.Greater(t, inicio, anterior)
+		anterior = inicio
+		return true
+	})
+	require.Equal(t, 100, anterior)
+
+	visitados := 0
+	arbol.IterarSolapados(50, 60, func(int, int, int) bool {
+		visitados++
+		return visitados < 3
+	})
+	require.Equal(t, 3, visitados, "La iteracion deberia cortarse al devolver false")
+
+	for intervalo := range arbol.Todos() {
+		if intervalo.Inicio == 3 {
+			break
+		}
+	}
+}
+
+func TestContraFuerzaBruta(t *testing.T) {
+	generador := rand.New(rand.NewSource(36))
+	arbol := TDAIntervalos.CrearArbolIntervalos[int, int](cmp.Compare[int])
+	var guardados []intervalo
+
+	for i := 0; i < 3000; i++ {
+		if len(guardados) > 0 && generador.Intn(3) == 0 {
+			j := generador.Intn(len(guardados))
+			arbol.Borrar(guardados[j].inicio, guardados[j].fin)
+			guardados = append(guardados[:j], guardados[j+1:]...)
+		} else {
+			inicio := generador.Intn(1000)
+			fin := inicio + generador.Intn(50)
+			arbol.Insertar(inicio, fin, i)
+			guardados = append(guardados, intervalo{inicio, fin, i})
+		}
+		require.Equal(t, len(guardados), arbol.Cantidad())
+
+		desde := generador.Intn(1000)
+		hasta := desde + generador.Intn(20)
+		var esperados []intervalo
+		for _, g := range guardados {
+			if g.inicio <= hasta && g.fin >= desde {
+				esperados = append(esperados, g)
+			}
+		}
+		obtenidos := solapados(arbol, desde, hasta)
+		require.Len(t, obtenidos, len(esperados))
+		for k := 1; k < len(obtenidos); k++ {
+			require.LessOrEqual(t, obtenidos[k-1].inicio, obtenidos[k].inicio, "Los solapados se recorren ordenados por inicio")
+		}
+		// Con intervalos repetidos el dato borrado puede ser cualquiera de ellos, asi que se comparan los extremos
+		extremos := func(intervalos []intervalo) [][2]int {
+			resultado := make([][2]int, len(intervalos))
+			for k, iv := range intervalos {
+				resultado[k] = [2]int{iv.inicio, iv.fin}
+			}
+			sort.Slice(resultado, func(a, b int) bool {
+				return resultado[a][0] < resultado[b][0] || resultado[a][0] == resultado[b][0] && resultado[a][1] < resultado[b][1]
+			})
+			return resultado
+		}
+		require.Equal(t, extremos(esperados), extremos(obtenidos))
+	}
+}
+
+func TestVolumenOrdenado(t *testing.T) {
+	// Los intervalos de un log llegan ordenados por inicio: sin balanceo el arbol seria una lista
+	arbol := TDAIntervalos.CrearArbolIntervalos[int, int](cmp.Compare[int])
+	for i := 0; i < 200000; i++ {
+		arbol.Insertar(i*10, i*10+5, i)
+	}
+	for i := 0; i < 200000; i++ {
+		require.Equal(t, []intervalo{{i * 10, i*10 + 5, i}}, solapados(arbol, i*10+5, i*10+7))
+	}
+}
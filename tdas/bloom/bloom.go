@@ -0,0 +1,33 @@
+package bloom
+
+// FiltroBloom responde si un elemento pudo haber sido agregado, usando memoria proporcional a la cantidad de
+// elementos esperada pero sin guardarlos. Nunca da falsos negativos: si PuedePertenecer devuelve false, el
+// elemento seguro no fue agregado. Puede dar falsos positivos con la tasa configurada al crearlo, siempre que no
+// se agreguen mas elementos que la capacidad indicada.
+type FiltroBloom interface {
+
+	// Agregar registra un elemento. Agregar varias veces el mismo elemento no modifica el filtro.
+	Agregar(elemento string)
+
+	// PuedePertenecer devuelve false si el elemento seguro no fue agregado, y true si probablemente lo fue.
+	PuedePertenecer(elemento string) bool
+
+	// TasaFalsosPositivos estima la probabilidad actual de falso positivo, segun la proporcion de posiciones
+	// ocupadas del filtro.
+	TasaFalsosPositivos() float64
+
+	// Serializar devuelve una representacion en bytes que se puede volver a cargar con la funcion Cargar
+	// correspondiente a la implementacion.
+	Serializar() []byte
+}
+
+// FiltroBloomContador es un FiltroBloom que ademas permite borrar elementos, a costa de usar un contador de un
+// byte por posicion en lugar de un bit.
+type FiltroBloomContador interface {
+	FiltroBloom
+
+	// Borrar quita una aparicion del elemento. Si el elemento seguro no fue agregado, entra en pánico con un
+	// mensaje 'El elemento no pertenece al filtro'. Borrar un elemento que no fue agregado pero da un falso
+	// positivo puede provocar falsos negativos en otros elementos.
+	Borrar(elemento string)
+}
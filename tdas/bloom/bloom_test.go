@@ -0,0 +1,135 @@
+package bloom_test
+
+import (
+	"fmt"
+	TDABloom "tdas/bloom"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	CAPACIDAD  = 10000
+	TASA_FP    = 0.01
+	CONSULTAS  = 100000
+	TOLERANCIA = 1.5
+)
+
+// tasaMedida agrega CAPACIDAD elementos al filtro y mide la proporcion de falsos positivos entre otros CONSULTAS
+// elementos que nunca se agregaron
+func tasaMedida(filtro TDABloom.FiltroBloom) float64 {
+	for i := 0; i < CAPACIDAD; i++ {
+		filtro.Agregar(fmt.Sprintf("agregado-%d", i))
+	}
+	falsosPositivos := 0
+	for i := 0; i < CONSULTAS; i++ {
+		if filtro.PuedePertenecer(fmt.Sprintf("consultado-%d", i)) {
+			falsosPositivos++
+		}
+	}
+	return float64(falsosPositivos) / CONSULTAS
+}
+
+func TestFiltroVacio(t *testing.T) {
+	filtro := TDABloom.CrearFiltroBloom(100, TASA_FP)
+	require.False(t, filtro.PuedePertenecer("hola"))
+	require.Equal(t, 0.0, filtro.TasaFalsosPositivos())
+}
+
+func TestParametrosInvalidos(t *testing.T) {
+	mensaje := "La capacidad debe ser positiva y la tasa de falsos positivos estar entre 0 y 1"
+	require.PanicsWithValue(t, mensaje, func() { TDABloom.CrearFiltroBloom(0, TASA_FP) })
+	require.PanicsWithValue(t, mensaje, func() { TDABloom.CrearFiltroBloom(10, 0) })
+	require.PanicsWithValue(t, mensaje, func() { TDABloom.CrearFiltroBloomContador(10, 1) })
+}
+
+func TestSinFalsosNegativos(t *testing.T) {
+	for _, filtro := range []TDABloom.FiltroBloom{TDABloom.CrearFiltroBloom(CAPACIDAD, TASA_FP), TDABloom.CrearFiltroBloomContador(CAPACIDAD, TASA_FP)} {
+		for i := 0; i < CAPACIDAD; i++ {
+			filtro.Agregar(fmt.Sprintf("ip-%d", i))
+		}
+		for i := 0; i < CAPACIDAD; i++ {
+			require.True(t, filtro.PuedePertenecer(fmt.Sprintf("ip-%d", i)), "Un filtro de Bloom no tiene falsos negativos")
+		}
+	}
+}
+
+func TestTasaDeFalsosPositivos(t *testing.T) {
+	for _, tasa := range []float64{0.1, 0.01, 0.001} {
+		filtro := TDABloom.CrearFiltroBloom(CAPACIDAD, tasa)
+		medida := tasaMedida(filtro)
+		require.LessOrEqual(t, medida, tasa*TOLERANCIA, "La tasa medida deberia acercarse a la configurada")
+		require.InDelta(t, tasa, filtro.TasaFalsosPositivos(), tasa*0.5, "La tasa estimada deberia acercarse a la configurada")
+	}
+	contador := TDABloom.CrearFiltroBloomContador(CAPACIDAD, TASA_FP)
+	require.LessOrEqual(t, tasaMedida(contador), TASA_FP*TOLERANCIA)
+}
+
+func TestSerializar(t *testing.T) {
+	filtro := TDABloom.CrearFiltroBloom(1000, TASA_FP)
+	for i := 0; i < 1000; i++ {
+		filtro.Agregar(fmt.Sprintf("%d", i))
+	}
+	cargado, err := TDABloom.CargarFiltroBloom(filtro.Serializar())
+	require.NoError(t, err)
+	require.Equal(t, filtro.Serializar(), cargado.Serializar())
+	for i := 0; i < 2000; i++ {
+		require.Equal(t, filtro.PuedePertenecer(fmt.Sprintf("%d", i)), cargado.PuedePertenecer(fmt.Sprintf("%d", i)))
+	}
+
+	contador := TDABloom.CrearFiltroBloomContador(1000, TASA_FP)
+	contador.Agregar("a")
+	contador.Agregar("a")
+	cargadoContador, err := TDABloom.CargarFiltroBloomContador(contador.Serializar())
+	require.NoError(t, err)
+	cargadoContador.Borrar("a")
+	require.True(t, cargadoContador.PuedePertenecer("a"), "Los contadores se deberian conservar al serializar")
+}
+
+func TestCargarDatosInvalidos(t *testing.T) {
+	datos := TDABloom.CrearFiltroBloom(100, TASA_FP).Serializar()
+	_, err := TDABloom.CargarFiltroBloom(nil)
+	require.Error(t, err)
+	_, err = TDABloom.CargarFiltroBloom(datos[:len(datos)-1])
+	require.Error(t, err)
+	_, err = TDABloom.CargarFiltroBloomContador(datos)
+	require.Error(t, err, "Un filtro de bits no se puede cargar como filtro con contadores")
+	_, err = TDABloom.CargarFiltroBloom(TDABloom.CrearFiltroBloomContador(100, TASA_FP).Serializar())
+	require.Error(t, err)
+}
+
+func TestFiltroContadorBorrar(t *testing.T) {
+	filtro := TDABloom.CrearFiltroBloomContador(CAPACIDAD, TASA_FP)
+	for i := 0; i < CAPACIDAD; i++ {
+		filtro.Agregar(fmt.Sprintf("ip-%d", i))
+	}
+	for i := 0; i < CAPACIDAD; i += 2 {
+		filtro.Borrar(fmt.Sprintf("ip-%d", i))
+	}
+	borradosPresentes := 0
+	for i := 0; i < CAPACIDAD; i++ {
+		presente := filtro.PuedePertenecer(fmt.Sprintf("ip-%d", i))
+		if i%2 == 1 {
+			require.True(t, presente, "Borrar no deberia afectar a los elementos que siguen agregados")
+		} else if presente {
+			borradosPresentes++
+		}
+	}
+	require.Less(t, float64(borradosPresentes), CAPACIDAD/2*TASA_FP*TOLERANCIA, "Los borrados solo pueden aparecer como falsos positivos")
+
+	for i := 1; i < CAPACIDAD; i += 2 {
+		filtro.Borrar(fmt.Sprintf("ip-%d", i))
+	}
+	require.Equal(t, 0.0, filtro.TasaFalsosPositivos(), "Al borrar todo el filtro deberia quedar vacio")
+	require.PanicsWithValue(t, "El elemento no pertenece al filtro", func() { filtro.Borrar("ip-1") })
+}
+
+func TestFiltroContadorRepetidos(t *testing.T) {
+	filtro := TDABloom.CrearFiltroBloomContador(100, TASA_FP)
+	filtro.Agregar("x")
+	filtro.Agregar("x")
+	filtro.Borrar("x")
+	require.True(t, filtro.PuedePertenecer("x"), "Agregado dos veces y borrado una, el elemento sigue estando")
+	filtro.Borrar("x")
+	require.False(t, filtro.PuedePertenecer("x"))
+}
@@ -0,0 +1,240 @@
+package bloom
+
+// Implementacion clasica de Bloom (1970): un arreglo de m posiciones y k funciones de hash. Las k posiciones de
+// cada elemento se obtienen con doble hashing (Kirsch y Mitzenmacher, 2006), a partir de dos hashes de 64 bits.
+//
+// Para n elementos y una tasa de falsos positivos p, el tamaño optimo es m = -n ln(p) / ln(2)^2 y la cantidad
+// de funciones de hash es k = (m/n) ln(2).
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/fnv"
+	"math"
+	"math/bits"
+)
+
+const (
+	// Se combina con el primer hash para derivar el segundo. Su valor es la base de FNV-1a y no se puede cambiar sin
+	// que los filtros guardados den otras posiciones
+	SEMILLA_SEGUNDO_HASH = 14695981039346656037
+	TIPO_BITS            = 1
+	TIPO_CONTADOR        = 2
+	LARGO_ENCABEZADO     = 10
+	CONTADOR_MAXIMO      = math.MaxUint8
+)
+
+var errorSerializacion = errors.New("datos de filtro de Bloom invalidos")
+
+// PRE:
+// POST: retorna el mensaje de error correspondiente al tipo proporcionado
+func mensajePanic(tipo string) string {
+	if tipo == "parametros" {
+		return "La capacidad debe ser positiva y la tasa de falsos positivos estar entre 0 y 1"
+	}
+	if tipo == "filtro" {
+		return "El elemento no pertenece al filtro"
+	}
+	return "Error desconocido"
+}
+
+type filtroBits struct {
+	bits       []uint64
+	posiciones uint64
+	funciones  int
+}
+
+type filtroContador struct {
+	contadores []uint8
+	funciones  int
+}
+
+// PRE:
+// POST: retorna la cantidad de posiciones y de funciones de hash optimas para la capacidad y tasa de falsos positivos indicadas
+func dimensionar(capacidad int, tasaFalsosPositivos float64) (uint64, int) {
+	if capacidad <= 0 || tasaFalsosPositivos <= 0 || tasaFalsosPositivos >= 1 {
+		panic(mensajePanic("parametros"))
+	}
+	posiciones := math.Ceil(-float64(capacidad) * math.Log(tasaFalsosPositivos) / (math.Ln2 * math.Ln2))
+	funciones := max(1, int(math.Round(posiciones/float64(capacidad)*math.Ln2)))
+	return uint64(posiciones), funciones
+}
+
+// CrearFiltroBloom crea un filtro vacio para la capacidad y tasa de falsos positivos indicadas. Si la capacidad
+// no es positiva o la tasa no esta entre 0 y 1, entra en panico.
+func CrearFiltroBloom(capacidad int, tasaFalsosPositivos float64) FiltroBloom {
+	posiciones, funciones := dimensionar(capacidad, tasaFalsosPositivos)
+	return &filtroBits{bits: make([]uint64, (posiciones+63)/64), posiciones: posiciones, funciones: funciones}
+}
+
+// CrearFiltroBloomContador crea un filtro con contadores vacio para la capacidad y tasa de falsos positivos
+// indicadas. Si la capacidad no es positiva o la tasa no esta entre 0 y 1, entra en panico.
+func CrearFiltroBloomContador(capacidad int, tasaFalsosPositivos float64) FiltroBloomContador {
+	posiciones, funciones := dimensionar(capacidad, tasaFalsosPositivos)
+	return &filtroContador{contadores: make([]uint8, posiciones), funciones: funciones}
+}
+
+// PRE:
+// POST: hashea el elemento con FNV-1a y mezcla el resultado (finalizador de MurmurHash3)
+func hashear(elemento string) uint64 {
+	fnv1a := fnv.New64a()
+	fnv1a.Write([]byte(elemento))
+	return mezclar(fnv1a.Sum64())
+}
+
+// PRE:
+// POST: retorna el hash con sus bits mezclados, de forma que cada bit de la entrada afecte a todos los de la salida
+func mezclar(hash uint64) uint64 {
+	hash ^= hash >> 33
+	hash *= 0xff51afd7ed558ccd
+	hash ^= hash >> 33
+	hash *= 0xc4ceb9fe1a85ec53
+	hash ^= hash >> 33
+	return hash
+}
+
+// PRE: posiciones debe de ser mayor a 0
+// POST: aplica visitar a las k posiciones del elemento mientras devuelva true
+func recorrerPosiciones(elemento string, posiciones uint64, funciones int, visitar func(posicion uint64) bool) {
+	h1 := hashear(elemento)
+	// El segundo hash es impar para que los pasos no se repitan cuando la cantidad de posiciones es par
+	h2 := mezclar(h1^SEMILLA_SEGUNDO_HASH) | 1
+	for i := 0; i < funciones; i++ {
+		if !visitar((h1 + uint64(i)*h2) % posiciones) {
+			return
+		}
+	}
+}
+
+// PRE: datos debe de tener al menos LARGO_ENCABEZADO bytes
+// POST: escribe el encabezado de la serializacion: el tipo de filtro, la cantidad de funciones y la de posiciones
+func escribirEncabezado(datos []byte, tipo byte, funciones int, posiciones uint64) {
+	datos[0] = tipo
+	datos[1] = byte(funciones)
+	binary.BigEndian.PutUint64(datos[2:LARGO_ENCABEZADO], posiciones)
+}
+
+// PRE:
+// POST: retorna la cantidad de funciones y de posiciones del encabezado, y false si no es de un filtro del tipo indicado
+func leerEncabezado(datos []byte, tipo byte) (int, uint64, bool) {
+	if len(datos) < LARGO_ENCABEZADO || datos[0] != tipo || datos[1] == 0 {
+		return 0, 0, false
+	}
+	posiciones := binary.BigEndian.Uint64(datos[2:LARGO_ENCABEZADO])
+	return int(datos[1]), posiciones, posiciones > 0
+}
+
+// CargarFiltroBloom devuelve el filtro serializado en datos por un FiltroBloom creado con CrearFiltroBloom, o un
+// error si los datos no son validos.
+func CargarFiltroBloom(datos []byte) (FiltroBloom, error) {
+	funciones, posiciones, ok := leerEncabezado(datos, TIPO_BITS)
+	palabras := (posiciones + 63) / 64
+	if !ok || uint64(len(datos)-LARGO_ENCABEZADO) != palabras*8 {
+		return nil, errorSerializacion
+	}
+	filtro := &filtroBits{bits: make([]uint64, palabras), posiciones: posiciones, funciones: funciones}
+	for i := range filtro.bits {
+		filtro.bits[i] = binary.BigEndian.Uint64(datos[LARGO_ENCABEZADO+8*i:])
+	}
+	return filtro, nil
+}
+
+// CargarFiltroBloomContador devuelve el filtro serializado en datos por un FiltroBloomContador creado con
+// CrearFiltroBloomContador, o un error si los datos no son validos.
+func CargarFiltroBloomContador(datos []byte) (FiltroBloomContador, error) {
+	funciones, posiciones, ok := leerEncabezado(datos, TIPO_CONTADOR)
+	if !ok || uint64(len(datos)-LARGO_ENCABEZADO) != posiciones {
+		return nil, errorSerializacion
+	}
+	filtro := &filtroContador{contadores: make([]uint8, posiciones), funciones: funciones}
+	copy(filtro.contadores, datos[LARGO_ENCABEZADO:])
+	return filtro, nil
+}
+
+// PRE: ocupadas no debe de ser mayor a posiciones
+// POST: retorna la probabilidad de que las k posiciones de un elemento no agregado esten todas ocupadas
+func tasaSegunOcupacion(ocupadas, posiciones uint64, funciones int) float64 {
+	return math.Pow(float64(ocupadas)/float64(posiciones), float64(funciones))
+}
+
+func (f *filtroBits) Agregar(elemento string) {
+	recorrerPosiciones(elemento, f.posiciones, f.funciones, func(posicion uint64) bool {
+		f.bits[posicion/64] |= 1 << (posicion % 64)
+		return true
+	})
+}
+
+func (f *filtroBits) PuedePertenecer(elemento string) bool {
+	pertenece := true
+	recorrerPosiciones(elemento, f.posiciones, f.funciones, func(posicion uint64) bool {
+		pertenece = f.bits[posicion/64]&(1<<(posicion%64)) != 0
+		return pertenece
+	})
+	return pertenece
+}
+
+func (f *filtroBits) TasaFalsosPositivos() float64 {
+	ocupadas := 0
+	for _, palabra := range f.bits {
+		ocupadas += bits.OnesCount64(palabra)
+	}
+	return tasaSegunOcupacion(uint64(ocupadas), f.posiciones, f.funciones)
+}
+
+func (f *filtroBits) Serializar() []byte {
+	datos := make([]byte, LARGO_ENCABEZADO+8*len(f.bits))
+	escribirEncabezado(datos, TIPO_BITS, f.funciones, f.posiciones)
+	for i, palabra := range f.bits {
+		binary.BigEndian.PutUint64(datos[LARGO_ENCABEZADO+8*i:], palabra)
+	}
+	return datos
+}
+
+// Los contadores que llegan a CONTADOR_MAXIMO quedan fijos: como ya no se sabe cuantas veces se incrementaron,
+// decrementarlos podria dejar en cero una posicion que otro elemento todavia usa
+func (f *filtroContador) Agregar(elemento string) {
+	recorrerPosiciones(elemento, uint64(len(f.contadores)), f.funciones, func(posicion uint64) bool {
+		if f.contadores[posicion] < CONTADOR_MAXIMO {
+			f.contadores[posicion]++
+		}
+		return true
+	})
+}
+
+func (f *filtroContador) PuedePertenecer(elemento string) bool {
+	pertenece := true
+	recorrerPosiciones(elemento, uint64(len(f.contadores)), f.funciones, func(posicion uint64) bool {
+		pertenece = f.contadores[posicion] > 0
+		return pertenece
+	})
+	return pertenece
+}
+
+func (f *filtroContador) Borrar(elemento string) {
+	if !f.PuedePertenecer(elemento) {
+		panic(mensajePanic("filtro"))
+	}
+	recorrerPosiciones(elemento, uint64(len(f.contadores)), f.funciones, func(posicion uint64) bool {
+		if f.contadores[posicion] < CONTADOR_MAXIMO {
+			f.contadores[posicion]--
+		}
+		return true
+	})
+}
+
+func (f *filtroContador) TasaFalsosPositivos() float64 {
+	ocupadas := uint64(0)
+	for _, contador := range f.contadores {
+		if contador > 0 {
+			ocupadas++
+		}
+	}
+	return tasaSegunOcupacion(ocupadas, uint64(len(f.contadores)), f.funciones)
+}
+
+func (f *filtroContador) Serializar() []byte {
+	datos := make([]byte, LARGO_ENCABEZADO+len(f.contadores))
+	escribirEncabezado(datos, TIPO_CONTADOR, f.funciones, uint64(len(f.contadores)))
+	copy(datos[LARGO_ENCABEZADO:], f.contadores)
+	return datos
+}
@@ -1,5 +1,7 @@
 package cola
 
+import "iter"
+
 type Cola[T any] interface {
 
 	// EstaVacia devuelve verdadero si la cola no tiene elementos encolados, false en caso contrario.
@@ -15,4 +17,8 @@ type Cola[T any] interface {
 	// Desencolar saca el primer elemento de la cola. Si la cola tiene elementos, se quita el primero de la misma,
 	// y se devuelve ese valor. Si está vacía, entra en pánico con un mensaje "La cola esta vacia".
 	Desencolar() T
+
+	// Todos devuelve un iter.Seq que recorre los elementos de la cola desde el primero hasta el ultimo, sin
+	// desencolarlos.
+	Todos() iter.Seq[T]
 }
@@ -0,0 +1,30 @@
+package cola
+
+// PoliticaDesborde indica que hace una ColaAcotada al encolar estando llena.
+type PoliticaDesborde int
+
+const (
+	// RECHAZAR no agrega el nuevo elemento: IntentarEncolar devuelve false, y Encolar entra en pánico con un
+	// mensaje "La cola esta llena".
+	RECHAZAR PoliticaDesborde = iota
+	// SOBRESCRIBIR descarta al primero de la cola para hacerle lugar al nuevo elemento.
+	SOBRESCRIBIR
+)
+
+type ColaAcotada[T any] interface {
+	Cola[T]
+
+	// EstaLlena devuelve verdadero si la cola tiene tantos elementos como su capacidad, false en caso contrario.
+	EstaLlena() bool
+
+	// Cantidad devuelve la cantidad de elementos encolados.
+	Cantidad() int
+
+	// Capacidad devuelve la cantidad maxima de elementos que puede tener la cola.
+	Capacidad() int
+
+	// IntentarEncolar agrega un nuevo elemento al final de la cola y devuelve true. Si la cola esta llena y la
+	// politica es RECHAZAR, no la modifica y devuelve false, en lugar de entrar en pánico como Encolar. Con la
+	// politica SOBRESCRIBIR siempre devuelve true.
+	IntentarEncolar(T) bool
+}
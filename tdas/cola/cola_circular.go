@@ -0,0 +1,142 @@
+package cola
+
+import "iter"
+
+const (
+	TAMANIO_INICIAL   int = 4
+	FACTOR_EXPANSION  int = 2
+	DIVISOR_REDUCCION int = 2
+	FACTOR_REDUCCION  int = 4
+)
+
+// Los elementos ocupan `cantidad` posiciones consecutivas del arreglo a partir de `primero`, dando la vuelta
+// al llegar al final. Si la cola es acotada nunca se redimensiona.
+type colaCircular[T any] struct {
+	datos    []T
+	primero  int
+	cantidad int
+	acotada  bool
+	politica PoliticaDesborde
+}
+
+// PRE:
+// POST: crea una cola circular que crece y se achica segun la cantidad de elementos
+func CrearColaCircular[T any]() Cola[T] {
+	return &colaCircular[T]{datos: make([]T, TAMANIO_INICIAL)}
+}
+
+// PRE: la capacidad debe de ser mayor a 0
+// POST: crea una cola circular de capacidad fija, que al estar llena se comporta segun la politica indicada
+func CrearColaAcotada[T any](capacidad int, politica PoliticaDesborde) ColaAcotada[T] {
+	if capacidad <= 0 {
+		panic("La capacidad debe ser mayor a 0")
+	}
+	return &colaCircular[T]{datos: make([]T, capacidad), acotada: true, politica: politica}
+}
+
+// PRE: i debe de estar entre 0 y la cantidad de elementos
+// POST: retorna la posicion en el arreglo del i-esimo elemento de la cola
+func (c *colaCircular[T]) posicion(i int) int {
+	return (c.primero + i) % len(c.datos)
+}
+
+// PRE: la capacidad nueva debe ser mayor o igual a la cantidad de elementos
+// POST: copia los elementos a un arreglo de la nueva capacidad, dejando al primero en la posicion 0
+func (c *colaCircular[T]) redimensionar(capacidadNueva int) {
+	nuevosDatos := make([]T, capacidadNueva)
+	for i := 0; i < c.cantidad; i++ {
+		nuevosDatos[i] = c.datos[c.posicion(i)]
+	}
+	c.datos = nuevosDatos
+	c.primero = 0
+}
+
+// PRE: la cola debe de existir
+// POST: retorna true si no hay elementos en la cola, en caso contrario retorna false
+func (c *colaCircular[T]) EstaVacia() bool {
+	return c.cantidad == 0
+}
+
+// PRE: la cola debe de existir
+// POST: retorna true si la cola ocupa todo el arreglo
+func (c *colaCircular[T]) EstaLlena() bool {
+	return c.cantidad == len(c.datos)
+}
+
+// PRE: la cola debe de existir
+// POST: retorna la cantidad de elementos encolados
+func (c *colaCircular[T]) Cantidad() int {
+	return c.cantidad
+}
+
+// PRE: la cola debe de existir
+// POST: retorna el tamaño del arreglo de la cola
+func (c *colaCircular[T]) Capacidad() int {
+	return len(c.datos)
+}
+
+// PRE: la cola no debe de estar vacia
+// POST: retorna el primer valor de la cola
+func (c *colaCircular[T]) VerPrimero() T {
+	if c.EstaVacia() {
+		panic(mensajePanic())
+	}
+	return c.datos[c.primero]
+}
+
+// PRE: la cola debe de existir
+// POST: el elemento es agregado al final de la cola. Si esta llena, se redimensiona o se aplica la politica
+// de desborde si es acotada
+func (c *colaCircular[T]) Encolar(valor T) {
+	if !c.IntentarEncolar(valor) {
+		panic("La cola esta llena")
+	}
+}
+
+// PRE: la cola debe de existir
+// POST: agrega el elemento al final de la cola y retorna true. Si esta llena se redimensiona, o se aplica la politica
+// de desborde si es acotada: con RECHAZAR no se agrega y se retorna false
+func (c *colaCircular[T]) IntentarEncolar(valor T) bool {
+	if c.EstaLlena() {
+		if !c.acotada {
+			c.redimensionar(len(c.datos) * FACTOR_EXPANSION)
+		} else if c.politica == SOBRESCRIBIR {
+			c.Desencolar()
+		} else {
+			return false
+		}
+	}
+	c.datos[c.posicion(c.cantidad)] = valor
+	c.cantidad++
+	return true
+}
+
+// PRE: debe de haber elementos en la cola
+// POST: el primer elemento es removido y retornado, achicando el arreglo si quedo poco ocupado
+func (c *colaCircular[T]) Desencolar() T {
+	if c.EstaVacia() {
+		panic(mensajePanic())
+	}
+	var vacio T
+	valor := c.datos[c.primero]
+	c.datos[c.primero] = vacio
+	c.primero = c.posicion(1)
+	c.cantidad--
+
+	if !c.acotada && c.cantidad*FACTOR_REDUCCION <= len(c.datos) && len(c.datos) > TAMANIO_INICIAL {
+		c.redimensionar(len(c.datos) / DIVISOR_REDUCCION)
+	}
+	return valor
+}
+
+// PRE: la cola debe de existir
+// POST: retorna un iter.Seq que recorre los elementos desde el primero hasta el ultimo sin modificar la cola
+func (c *colaCircular[T]) Todos() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < c.cantidad; i++ {
+			if !yield(c.datos[c.posicion(i)]) {
+				return
+			}
+		}
+	}
+}
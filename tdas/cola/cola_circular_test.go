@@ -0,0 +1,113 @@
+package cola_test
+
+import (
+	TDACola "tdas/cola"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// PRE:
+// POST: devuelve los elementos de la cola en orden, sin desencolarlos
+func elementosDe(cola TDACola.Cola[int]) []int {
+	elementos := []int{}
+	for elem := range cola.Todos() {
+		elementos = append(elementos, elem)
+	}
+	return elementos
+}
+
+func TestColaCircularVacia(t *testing.T) {
+	cola := TDACola.CrearColaCircular[int]()
+	require.True(t, cola.EstaVacia(), "La cola deberia estar vacía al inicializarla")
+	require.PanicsWithValue(t, "La cola esta vacia", func() { cola.Desencolar() })
+	require.PanicsWithValue(t, "La cola esta vacia", func() { cola.VerPrimero() })
+}
+
+func TestColaCircularFIFO(t *testing.T) {
+	cola := TDACola.CrearColaCircular[int]()
+
+	// Intercalamos encolar y desencolar para que los elementos den la vuelta al arreglo
+	for i := 0; i < 3; i++ {
+		cola.Encolar(i)
+	}
+	for i := 0; i < 10; i++ {
+		require.Equal(t, i, cola.Desencolar(), "El elemento desencolado deberia ser %d", i)
+		cola.Encolar(i + 3)
+	}
+	require.Equal(t, []int{10, 11, 12}, elementosDe(cola))
+	require.Equal(t, 10, cola.VerPrimero())
+}
+
+func TestColaCircularVolumen(t *testing.T) {
+	cola := TDACola.CrearColaCircular[int]()
+	for i := 0; i < 100000; i++ {
+		cola.Encolar(i)
+	}
+	for i := 0; i < 100000; i++ {
+		require.Equal(t, i, cola.VerPrimero(), "El primero deberia ser %d", i)
+		require.Equal(t, i, cola.Desencolar(), "El elemento desencolado deberia ser %d", i)
+	}
+	require.True(t, cola.EstaVacia(), "La cola deberia estar vacia despues de desencolar todos los elementos")
+
+	// Al vaciarse se comporta como recien creada
+	require.PanicsWithValue(t, "La cola esta vacia", func() { cola.Desencolar() })
+	cola.Encolar(1)
+	require.Equal(t, 1, cola.VerPrimero())
+}
+
+func TestColaAcotadaRechazar(t *testing.T) {
+	cola := TDACola.CrearColaAcotada[int](3, TDACola.RECHAZAR)
+	require.Equal(t, 3, cola.Capacidad())
+	for i := 1; i <= 3; i++ {
+		require.False(t, cola.EstaLlena(), "La cola no deberia estar llena con %d elementos", i-1)
+		cola.Encolar(i)
+	}
+	require.True(t, cola.EstaLlena(), "La cola deberia estar llena")
+	require.PanicsWithValue(t, "La cola esta llena", func() { cola.Encolar(4) })
+	require.Equal(t, []int{1, 2, 3}, elementosDe(cola), "Encolar en una cola llena no deberia modificarla")
+
+	// Al desencolar se libera lugar, y la capacidad no cambia
+	require.Equal(t, 1, cola.Desencolar())
+	cola.Encolar(4)
+	require.Equal(t, []int{2, 3, 4}, elementosDe(cola))
+	require.Equal(t, 3, cola.Capacidad())
+	require.Equal(t, 3, cola.Cantidad())
+
+	require.Panics(t, func() { TDACola.CrearColaAcotada[int](0, TDACola.RECHAZAR) }, "La capacidad debe ser positiva")
+}
+
+func TestColaAcotadaIntentarEncolar(t *testing.T) {
+	cola := TDACola.CrearColaAcotada[int](2, TDACola.RECHAZAR)
+	require.True(t, cola.IntentarEncolar(1))
+	require.True(t, cola.IntentarEncolar(2))
+	require.False(t, cola.IntentarEncolar(3), "Una cola llena que rechaza no deberia aceptar el elemento")
+	require.Equal(t, []int{1, 2}, elementosDe(cola), "Rechazar el elemento no deberia modificar la cola")
+
+	cola.Desencolar()
+	require.True(t, cola.IntentarEncolar(3))
+	require.Equal(t, []int{2, 3}, elementosDe(cola))
+
+	ventana := TDACola.CrearColaAcotada[int](2, TDACola.SOBRESCRIBIR)
+	for i := 1; i <= 3; i++ {
+		require.True(t, ventana.IntentarEncolar(i), "Una cola que sobrescribe siempre acepta el elemento")
+	}
+	require.Equal(t, []int{2, 3}, elementosDe(ventana))
+}
+
+func TestColaAcotadaSobrescribir(t *testing.T) {
+	// Una ventana con los ultimos 5 elementos encolados
+	ventana := TDACola.CrearColaAcotada[int](5, TDACola.SOBRESCRIBIR)
+	for i := 1; i <= 12; i++ {
+		ventana.Encolar(i)
+		require.LessOrEqual(t, ventana.Cantidad(), 5, "La cola no deberia superar su capacidad")
+	}
+	require.True(t, ventana.EstaLlena())
+	require.Equal(t, []int{8, 9, 10, 11, 12}, elementosDe(ventana), "Se deberian haber descartado los mas viejos")
+	require.Equal(t, 8, ventana.VerPrimero())
+
+	for i := 8; i <= 12; i++ {
+		require.Equal(t, i, ventana.Desencolar())
+	}
+	require.True(t, ventana.EstaVacia())
+}
@@ -1,5 +1,7 @@
 package cola
 
+import "iter"
+
 type colaEnlazada[T any] struct {
 	primero *nodoCola[T]
 	ultimo  *nodoCola[T]
@@ -67,3 +69,15 @@ func (c *colaEnlazada[T]) Desencolar() T {
 	}
 	return valor
 }
+
+// PRE: la cola debe de existir
+// POST: retorna un iter.Seq que recorre los elementos desde el primero hasta el ultimo sin modificar la cola
+func (c *colaEnlazada[T]) Todos() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for actual := c.primero; actual != nil; actual = actual.prox {
+			if !yield(actual.dato) {
+				return
+			}
+		}
+	}
+}
@@ -108,3 +108,28 @@ func TestVolumenCola(t *testing.T) {
 	// Verificamos que la cola haya quedado vacia
 	require.True(t, cola.EstaVacia(), "La cola deberia estar vacía despues de desencolar todos los elementos")
 }
+
+func TestRangeCola(t *testing.T) {
+	cola := TDACola.CrearColaEnlazada[int]()
+	for i := 1; i <= 5; i++ {
+		cola.Encolar(i)
+	}
+
+	// Recorrer con range va del primero al ultimo sin desencolar
+	recorridos := []int{}
+	for elem := range cola.Todos() {
+		recorridos = append(recorridos, elem)
+	}
+	require.Equal(t, []int{1, 2, 3, 4, 5}, recorridos, "Se espera recorrer la cola en orden FIFO")
+	require.Equal(t, 1, cola.VerPrimero(), "Recorrer la cola no deberia modificarla")
+
+	// Cortar el ciclo con break deja de recorrer
+	recorridos = []int{}
+	for elem := range cola.Todos() {
+		if elem == 3 {
+			break
+		}
+		recorridos = append(recorridos, elem)
+	}
+	require.Equal(t, []int{1, 2}, recorridos, "Se espera que el break corte la iteracion")
+}
@@ -1,5 +1,7 @@
 package cola_prioridad
 
+import "iter"
+
 type ColaPrioridad[T any] interface {
 
 	// EstaVacia devuelve true si la la cola se encuentra vacía, false en caso contrario.
@@ -18,4 +20,8 @@ type ColaPrioridad[T any] interface {
 
 	// Cantidad devuelve la cantidad de elementos que hay en la cola de prioridad.
 	Cantidad() int
+
+	// DesencolarTodos devuelve un iter.Seq que va desencolando los elementos en orden de prioridad. Si se corta
+	// el ciclo con break, los elementos que no se llegaron a recorrer quedan en la cola.
+	DesencolarTodos() iter.Seq[T]
 }
@@ -0,0 +1,37 @@
+package cola_prioridad
+
+type ColaPrioridadIndexada[K comparable, T any] interface {
+
+	// EstaVacia devuelve true si la la cola se encuentra vacía, false en caso contrario.
+	EstaVacia() bool
+
+	// Encolar agrega un elemento identificado por la clave dada. Si la clave ya se encontraba, se actualiza
+	// su prioridad con el nuevo dato.
+	Encolar(clave K, dato T)
+
+	// Contiene devuelve true si hay un elemento encolado con la clave dada, false en caso contrario.
+	Contiene(clave K) bool
+
+	// Obtener devuelve el dato asociado a la clave. Si la clave no se encuentra, entra en pánico con un mensaje
+	// "La clave no pertenece a la cola".
+	Obtener(clave K) T
+
+	// ActualizarPrioridad reemplaza el dato asociado a la clave, reubicandolo segun su nueva prioridad. Si la clave
+	// no se encuentra, entra en pánico con un mensaje "La clave no pertenece a la cola".
+	ActualizarPrioridad(clave K, nuevo T)
+
+	// Borrar elimina el elemento asociado a la clave y devuelve su dato. Si la clave no se encuentra, entra en
+	// pánico con un mensaje "La clave no pertenece a la cola".
+	Borrar(clave K) T
+
+	// VerMax devuelve la clave y el dato del elemento con máxima prioridad. Si está vacía, entra en pánico con un
+	// mensaje "La cola esta vacia".
+	VerMax() (K, T)
+
+	// Desencolar elimina el elemento con máxima prioridad, y devuelve su clave y su dato. Si está vacía, entra en
+	// pánico con un mensaje "La cola esta vacia"
+	Desencolar() (K, T)
+
+	// Cantidad devuelve la cantidad de elementos que hay en la cola de prioridad.
+	Cantidad() int
+}
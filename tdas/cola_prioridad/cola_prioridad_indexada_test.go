@@ -0,0 +1,127 @@
+package cola_prioridad_test
+
+import (
+	"fmt"
+	"math/rand"
+	TDAHeap "tdas/cola_prioridad"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeapIndexadoVacio(t *testing.T) {
+	heap := TDAHeap.CrearHeapIndexado[string, int](cmpInt)
+
+	require.True(t, heap.EstaVacia(), "El heap debe de estar vacio al ser creado")
+	require.Equal(t, 0, heap.Cantidad(), "Se espera que la cantidad de un heap vacio sea 0")
+	require.False(t, heap.Contiene("A"), "Un heap vacio no contiene ninguna clave")
+	require.PanicsWithValue(t, "La cola esta vacia", func() { heap.VerMax() })
+	require.PanicsWithValue(t, "La cola esta vacia", func() { heap.Desencolar() })
+	require.PanicsWithValue(t, "La clave no pertenece a la cola", func() { heap.Obtener("A") })
+	require.PanicsWithValue(t, "La clave no pertenece a la cola", func() { heap.Borrar("A") })
+	require.PanicsWithValue(t, "La clave no pertenece a la cola", func() { heap.ActualizarPrioridad("A", 1) })
+}
+
+func TestHeapIndexadoEncolarYDesencolar(t *testing.T) {
+	heap := TDAHeap.CrearHeapIndexado[string, int](cmpInt)
+	heap.Encolar("/album/presto", 2)
+	heap.Encolar("/album/movingpictures", 3)
+	heap.Encolar("/album/clockworkangels", 1)
+
+	require.Equal(t, 3, heap.Cantidad())
+	require.True(t, heap.Contiene("/album/presto"))
+	require.Equal(t, 2, heap.Obtener("/album/presto"))
+
+	clave, dato := heap.VerMax()
+	require.Equal(t, "/album/movingpictures", clave, "El maximo deberia ser el recurso con mas visitas")
+	require.Equal(t, 3, dato)
+
+	// Encolar una clave existente actualiza su prioridad en lugar de duplicarla
+	heap.Encolar("/album/clockworkangels", 5)
+	require.Equal(t, 3, heap.Cantidad(), "Encolar una clave existente no deberia agregar un elemento")
+
+	claves := []string{}
+	for !heap.EstaVacia() {
+		clave, _ := heap.Desencolar()
+		claves = append(claves, clave)
+		require.False(t, heap.Contiene(clave), "La clave desencolada no deberia seguir en el heap")
+	}
+	require.Equal(t, []string{"/album/clockworkangels", "/album/movingpictures", "/album/presto"}, claves)
+}
+
+func TestHeapIndexadoActualizarPrioridad(t *testing.T) {
+	heap := TDAHeap.CrearHeapIndexado[int, int](cmpInt)
+	for i := 0; i < 10; i++ {
+		heap.Encolar(i, i)
+	}
+
+	// Aumentar la prioridad lleva al elemento al tope
+	heap.ActualizarPrioridad(2, 100)
+	clave, dato := heap.VerMax()
+	require.Equal(t, 2, clave)
+	require.Equal(t, 100, dato)
+
+	// Disminuirla lo saca del tope
+	heap.ActualizarPrioridad(2, -1)
+	clave, _ = heap.VerMax()
+	require.Equal(t, 9, clave)
+
+	anterior := 10
+	for !heap.EstaVacia() {
+		_, dato := heap.Desencolar()
+		require.LessOrEqual(t, dato, anterior, "Se espera desencolar en orden de prioridad")
+		anterior = dato
+	}
+	require.Equal(t, -1, anterior, "El ultimo desencolado deberia ser el de menor prioridad")
+}
+
+func TestHeapIndexadoBorrar(t *testing.T) {
+	heap := TDAHeap.CrearHeapIndexado[string, int](cmpInt)
+	for i := 0; i < 20; i++ {
+		heap.Encolar(fmt.Sprintf("ip%d", i), i)
+	}
+
+	require.Equal(t, 19, heap.Borrar("ip19"), "Borrar el maximo deberia devolver su dato")
+	require.Equal(t, 7, heap.Borrar("ip7"))
+	require.False(t, heap.Contiene("ip7"))
+	require.PanicsWithValue(t, "La clave no pertenece a la cola", func() { heap.Borrar("ip7") })
+	require.Equal(t, 18, heap.Cantidad())
+
+	clave, _ := heap.VerMax()
+	require.Equal(t, "ip18", clave, "Luego de borrar el maximo, el siguiente pasa al tope")
+}
+
+func TestHeapIndexadoVolumen(t *testing.T) {
+	const cantidad = 5000
+	heap := TDAHeap.CrearHeapIndexado[int, int](cmpInt)
+	prioridades := make([]int, cantidad)
+	for i := 0; i < cantidad; i++ {
+		prioridades[i] = rand.Intn(cantidad)
+		heap.Encolar(i, prioridades[i])
+	}
+
+	// Mezclamos actualizaciones y borrados al azar
+	borrados := 0
+	for i := 0; i < cantidad; i++ {
+		clave := rand.Intn(cantidad)
+		if !heap.Contiene(clave) {
+			continue
+		}
+		if i%3 == 0 {
+			require.Equal(t, prioridades[clave], heap.Borrar(clave))
+			borrados++
+		} else {
+			prioridades[clave] = rand.Intn(cantidad)
+			heap.ActualizarPrioridad(clave, prioridades[clave])
+		}
+	}
+	require.Equal(t, cantidad-borrados, heap.Cantidad())
+
+	anterior := cantidad
+	for !heap.EstaVacia() {
+		clave, dato := heap.Desencolar()
+		require.Equal(t, prioridades[clave], dato, "El dato desencolado deberia ser la ultima prioridad asignada")
+		require.LessOrEqual(t, dato, anterior, "Se espera desencolar en orden de prioridad")
+		anterior = dato
+	}
+}
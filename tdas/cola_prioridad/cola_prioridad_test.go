@@ -262,3 +262,27 @@ func TestHeapCrearArrGeneral(t *testing.T) {
 	require.Equal(t, 25, heap.VerMax(), "El nuevo maximo del arreglo deberia de ser '25'")
 	require.Equal(t, 5, heap.Cantidad(), "La cantidad del arreglo debe de ser '5'")
 }
+
+func TestHeapDesencolarTodos(t *testing.T) {
+	heap := TDAHeap.CrearHeapArr([]int{4, 9, 1, 7, 3, 8}, cmpInt)
+
+	// Se desencolan los elementos en orden de prioridad hasta cortar el ciclo
+	recorridos := []int{}
+	for elem := range heap.DesencolarTodos() {
+		recorridos = append(recorridos, elem)
+		if elem == 7 {
+			break
+		}
+	}
+	require.Equal(t, []int{9, 8, 7}, recorridos, "Se espera desencolar en orden de prioridad")
+	require.Equal(t, 3, heap.Cantidad(), "Los elementos no recorridos deben quedar en el heap")
+	require.Equal(t, 4, heap.VerMax(), "El maximo restante deberia ser '4'")
+
+	// Al terminar el ciclo el heap queda vacio
+	recorridos = []int{}
+	for elem := range heap.DesencolarTodos() {
+		recorridos = append(recorridos, elem)
+	}
+	require.Equal(t, []int{4, 3, 1}, recorridos, "Se espera desencolar el resto en orden de prioridad")
+	require.True(t, heap.EstaVacia(), "El heap deberia quedar vacio")
+}
@@ -1,5 +1,7 @@
 package cola_prioridad
 
+import "iter"
+
 const (
 	TAMANIO_INICIAL   int = 4
 	FACTOR_EXPANSION  int = 2
@@ -177,3 +179,14 @@ func downHeap[T any](h *colaPrioridad[T], i int) {
 func (h *colaPrioridad[T]) Cantidad() int {
 	return h.cantidad
 }
+
+// Implementacion Primitiva: DesencolarTodos
+func (h *colaPrioridad[T]) DesencolarTodos() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for !h.EstaVacia() {
+			if !yield(h.Desencolar()) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package cola_prioridad
+
+import TDADICC "tdas/diccionario"
+
+type elementoIndexado[K comparable, T any] struct {
+	clave K
+	dato  T
+}
+
+// Las posiciones de cada clave dentro de `datos` se guardan en un hash, asi las operaciones por clave
+// encuentran al elemento en O(1) y solo pagan el O(log n) de reubicarlo.
+type heapIndexado[K comparable, T any] struct {
+	datos       []elementoIndexado[K, T]
+	posiciones  TDADICC.Diccionario[K, int]
+	funcion_cmp func(T, T) int
+}
+
+func CrearHeapIndexado[K comparable, T any](funcion_cmp func(T, T) int) ColaPrioridadIndexada[K, T] {
+	return &heapIndexado[K, T]{
+		datos:       make([]elementoIndexado[K, T], 0, TAMANIO_INICIAL),
+		posiciones:  TDADICC.CrearHash[K, int](),
+		funcion_cmp: funcion_cmp,
+	}
+}
+
+// PRE:
+// POST: retorna un mensaje de panico para una clave que no esta encolada
+func mensajePanicClave() string {
+	return "La clave no pertenece a la cola"
+}
+
+// PRE: i y j tienen que ser indices validos en el arreglo h.datos.
+// POST: se intercambian los elementos en las posiciones i y j, actualizando sus posiciones
+func (h *heapIndexado[K, T]) swap(i, j int) {
+	h.datos[i], h.datos[j] = h.datos[j], h.datos[i]
+	h.posiciones.Guardar(h.datos[i].clave, i)
+	h.posiciones.Guardar(h.datos[j].clave, j)
+}
+
+// PRE: el heap es valido, excepto por el elemento en la posicion indicada.
+// POST: el elemento sube hasta que el heap cumple con la propiedad de orden.
+func (h *heapIndexado[K, T]) upheap(elem int) {
+	if elem == 0 {
+		return
+	}
+	padre := (elem - 1) / 2
+	if h.funcion_cmp(h.datos[elem].dato, h.datos[padre].dato) > 0 {
+		h.swap(elem, padre)
+		h.upheap(padre)
+	}
+}
+
+// PRE: el heap es valido, excepto por el elemento en la posicion indicada.
+// POST: el elemento baja hasta que el heap cumple con la propiedad de orden.
+func (h *heapIndexado[K, T]) downHeap(i int) {
+	hijoIzq := hijo(i, "izquierdo")
+	if hijoIzq >= len(h.datos) {
+		return
+	}
+	hijoDer := hijo(i, "derecho")
+	hijoMayor := hijoIzq
+
+	if hijoDer < len(h.datos) && h.funcion_cmp(h.datos[hijoDer].dato, h.datos[hijoIzq].dato) >= 0 {
+		hijoMayor = hijoDer
+	}
+	if h.funcion_cmp(h.datos[i].dato, h.datos[hijoMayor].dato) >= 0 {
+		return
+	}
+	h.swap(i, hijoMayor)
+	h.downHeap(hijoMayor)
+}
+
+// PRE: la clave debe de estar encolada
+// POST: retorna la posicion de la clave en el arreglo, si no esta encolada entra en panico
+func (h *heapIndexado[K, T]) posicion(clave K) int {
+	if !h.posiciones.Pertenece(clave) {
+		panic(mensajePanicClave())
+	}
+	return h.posiciones.Obtener(clave)
+}
+
+// PRE: i tiene que ser un indice valido en el arreglo h.datos.
+// POST: elimina el elemento en la posicion i, reemplazandolo por el ultimo y reubicando a este
+func (h *heapIndexado[K, T]) borrarPosicion(i int) elementoIndexado[K, T] {
+	ultimo := len(h.datos) - 1
+	h.swap(i, ultimo)
+	eliminado := h.datos[ultimo]
+	h.datos = h.datos[:ultimo]
+	h.posiciones.Borrar(eliminado.clave)
+	if i < ultimo {
+		h.downHeap(i)
+		h.upheap(i)
+	}
+	return eliminado
+}
+
+func (h *heapIndexado[K, T]) EstaVacia() bool {
+	return len(h.datos) == 0
+}
+
+func (h *heapIndexado[K, T]) Encolar(clave K, dato T) {
+	if h.posiciones.Pertenece(clave) {
+		h.ActualizarPrioridad(clave, dato)
+		return
+	}
+	h.datos = append(h.datos, elementoIndexado[K, T]{clave: clave, dato: dato})
+	h.posiciones.Guardar(clave, len(h.datos)-1)
+	h.upheap(len(h.datos) - 1)
+}
+
+func (h *heapIndexado[K, T]) Contiene(clave K) bool {
+	return h.posiciones.Pertenece(clave)
+}
+
+func (h *heapIndexado[K, T]) Obtener(clave K) T {
+	return h.datos[h.posicion(clave)].dato
+}
+
+func (h *heapIndexado[K, T]) ActualizarPrioridad(clave K, nuevo T) {
+	i := h.posicion(clave)
+	h.datos[i].dato = nuevo
+	h.upheap(i)
+	h.downHeap(h.posiciones.Obtener(clave))
+}
+
+func (h *heapIndexado[K, T]) Borrar(clave K) T {
+	return h.borrarPosicion(h.posicion(clave)).dato
+}
+
+func (h *heapIndexado[K, T]) VerMax() (K, T) {
+	if h.EstaVacia() {
+		panic(mensajePanic())
+	}
+	return h.datos[0].clave, h.datos[0].dato
+}
+
+func (h *heapIndexado[K, T]) Desencolar() (K, T) {
+	if h.EstaVacia() {
+		panic(mensajePanic())
+	}
+	max := h.borrarPosicion(0)
+	return max.clave, max.dato
+}
+
+func (h *heapIndexado[K, T]) Cantidad() int {
+	return len(h.datos)
+}
@@ -0,0 +1,54 @@
+package deque
+
+import "iter"
+
+type Deque[T any] interface {
+
+	// EstaVacia devuelve verdadero si el deque no tiene elementos, false en caso contrario.
+	EstaVacia() bool
+
+	// Cantidad devuelve la cantidad de elementos en el deque.
+	Cantidad() int
+
+	// InsertarFrente agrega un nuevo elemento al frente del deque.
+	InsertarFrente(T)
+
+	// InsertarFinal agrega un nuevo elemento al final del deque.
+	InsertarFinal(T)
+
+	// VerFrente obtiene el valor del frente del deque. Si está vacío, entra en pánico con un mensaje
+	// "El deque esta vacio".
+	VerFrente() T
+
+	// VerFinal obtiene el valor del final del deque. Si está vacío, entra en pánico con un mensaje
+	// "El deque esta vacio".
+	VerFinal() T
+
+	// BorrarFrente saca el elemento del frente del deque y lo devuelve. Si está vacío, entra en pánico con un
+	// mensaje "El deque esta vacio".
+	BorrarFrente() T
+
+	// BorrarFinal saca el elemento del final del deque y lo devuelve. Si está vacío, entra en pánico con un
+	// mensaje "El deque esta vacio".
+	BorrarFinal() T
+
+	// Iterador devuelve un IteradorDeque posicionado en el frente del deque.
+	Iterador() IteradorDeque[T]
+
+	// Todos devuelve un iter.Seq que recorre los elementos del deque desde el frente hasta el final.
+	Todos() iter.Seq[T]
+}
+
+type IteradorDeque[T any] interface {
+
+	// HaySiguiente devuelve verdadero si el iterador esta posicionado sobre un elemento del deque.
+	HaySiguiente() bool
+
+	// VerActual devuelve el elemento actual en el que está posicionado el iterador. Si no HaySiguiente, entra en
+	// pánico con un mensaje "El iterador termino de iterar".
+	VerActual() T
+
+	// Siguiente avanza el iterador hacia el final del deque. Si no HaySiguiente, entra en pánico con un mensaje
+	// "El iterador termino de iterar".
+	Siguiente()
+}
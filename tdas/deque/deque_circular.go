@@ -0,0 +1,181 @@
+package deque
+
+import "iter"
+
+const (
+	TAMANIO_INICIAL   int = 4
+	FACTOR_EXPANSION  int = 2
+	DIVISOR_REDUCCION int = 2
+	FACTOR_REDUCCION  int = 4
+)
+
+// Los elementos ocupan `cantidad` posiciones consecutivas del arreglo a partir de `frente`, dando la vuelta
+// al llegar a cualquiera de los extremos.
+type dequeCircular[T any] struct {
+	datos    []T
+	frente   int
+	cantidad int
+}
+
+type iteradorDeque[T any] struct {
+	deque  *dequeCircular[T]
+	actual int
+}
+
+// PRE:
+// POST: retorna un mensaje de deque vacio
+func mensajePanic() string {
+	return "El deque esta vacio"
+}
+
+// PRE:
+// POST: crea un deque vacio que crece y se achica segun la cantidad de elementos
+func CrearDeque[T any]() Deque[T] {
+	return &dequeCircular[T]{datos: make([]T, TAMANIO_INICIAL)}
+}
+
+// PRE: i debe de estar entre -1 y la cantidad de elementos
+// POST: retorna la posicion en el arreglo del i-esimo elemento contando desde el frente
+func (d *dequeCircular[T]) posicion(i int) int {
+	return (d.frente + i + len(d.datos)) % len(d.datos)
+}
+
+// PRE: la capacidad nueva debe ser mayor o igual a la cantidad de elementos
+// POST: copia los elementos a un arreglo de la nueva capacidad, dejando al frente en la posicion 0
+func (d *dequeCircular[T]) redimensionar(capacidadNueva int) {
+	nuevosDatos := make([]T, capacidadNueva)
+	for i := 0; i < d.cantidad; i++ {
+		nuevosDatos[i] = d.datos[d.posicion(i)]
+	}
+	d.datos = nuevosDatos
+	d.frente = 0
+}
+
+// PRE: el deque debe de existir
+// POST: agranda el arreglo si no queda lugar para un elemento mas
+func (d *dequeCircular[T]) verificarExpansion() {
+	if d.cantidad == len(d.datos) {
+		d.redimensionar(len(d.datos) * FACTOR_EXPANSION)
+	}
+}
+
+// PRE: el deque debe de existir
+// POST: achica el arreglo si quedo poco ocupado
+func (d *dequeCircular[T]) verificarReduccion() {
+	if d.cantidad*FACTOR_REDUCCION <= len(d.datos) && len(d.datos) > TAMANIO_INICIAL {
+		d.redimensionar(len(d.datos) / DIVISOR_REDUCCION)
+	}
+}
+
+// PRE:
+// POST: retorna true si el deque no tiene elementos
+func (d *dequeCircular[T]) EstaVacia() bool {
+	return d.cantidad == 0
+}
+
+// PRE:
+// POST: retorna la cantidad de elementos del deque
+func (d *dequeCircular[T]) Cantidad() int {
+	return d.cantidad
+}
+
+// PRE:
+// POST: el elemento es agregado antes del frente, pasando a ser el nuevo frente
+func (d *dequeCircular[T]) InsertarFrente(elemento T) {
+	d.verificarExpansion()
+	d.frente = d.posicion(-1)
+	d.datos[d.frente] = elemento
+	d.cantidad++
+}
+
+// PRE:
+// POST: el elemento es agregado despues del final, pasando a ser el nuevo final
+func (d *dequeCircular[T]) InsertarFinal(elemento T) {
+	d.verificarExpansion()
+	d.datos[d.posicion(d.cantidad)] = elemento
+	d.cantidad++
+}
+
+// PRE: el deque no debe de estar vacio
+// POST: retorna el elemento del frente
+func (d *dequeCircular[T]) VerFrente() T {
+	if d.EstaVacia() {
+		panic(mensajePanic())
+	}
+	return d.datos[d.frente]
+}
+
+// PRE: el deque no debe de estar vacio
+// POST: retorna el elemento del final
+func (d *dequeCircular[T]) VerFinal() T {
+	if d.EstaVacia() {
+		panic(mensajePanic())
+	}
+	return d.datos[d.posicion(d.cantidad-1)]
+}
+
+// PRE: el deque no debe de estar vacio
+// POST: el elemento del frente es removido y retornado
+func (d *dequeCircular[T]) BorrarFrente() T {
+	if d.EstaVacia() {
+		panic(mensajePanic())
+	}
+	var vacio T
+	elemento := d.datos[d.frente]
+	d.datos[d.frente] = vacio
+	d.frente = d.posicion(1)
+	d.cantidad--
+	d.verificarReduccion()
+	return elemento
+}
+
+// PRE: el deque no debe de estar vacio
+// POST: el elemento del final es removido y retornado
+func (d *dequeCircular[T]) BorrarFinal() T {
+	if d.EstaVacia() {
+		panic(mensajePanic())
+	}
+	var vacio T
+	ultimo := d.posicion(d.cantidad - 1)
+	elemento := d.datos[ultimo]
+	d.datos[ultimo] = vacio
+	d.cantidad--
+	d.verificarReduccion()
+	return elemento
+}
+
+// PRE:
+// POST: retorna un iterador posicionado en el frente del deque
+func (d *dequeCircular[T]) Iterador() IteradorDeque[T] {
+	return &iteradorDeque[T]{deque: d}
+}
+
+// PRE:
+// POST: retorna un iter.Seq que recorre los elementos desde el frente hasta el final sin modificar el deque
+func (d *dequeCircular[T]) Todos() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < d.cantidad; i++ {
+			if !yield(d.datos[d.posicion(i)]) {
+				return
+			}
+		}
+	}
+}
+
+func (it *iteradorDeque[T]) HaySiguiente() bool {
+	return it.actual < it.deque.cantidad
+}
+
+func (it *iteradorDeque[T]) VerActual() T {
+	if !it.HaySiguiente() {
+		panic("El iterador termino de iterar")
+	}
+	return it.deque.datos[it.deque.posicion(it.actual)]
+}
+
+func (it *iteradorDeque[T]) Siguiente() {
+	if !it.HaySiguiente() {
+		panic("El iterador termino de iterar")
+	}
+	it.actual++
+}
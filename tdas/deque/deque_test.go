@@ -0,0 +1,142 @@
+package deque_test
+
+import (
+	TDADeque "tdas/deque"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDequeVacio(t *testing.T) {
+	deque := TDADeque.CrearDeque[int]()
+	// Verificar que el deque esta vacio al inicio y que la cantidad de elementos es 0
+	require.True(t, deque.EstaVacia(), "El deque deberia estar vacio al inicializarlo")
+	require.Equal(t, 0, deque.Cantidad(), "El deque deberia tener 0 elementos al inicializarlo")
+
+	// Ver y borrar en cualquiera de los extremos de un deque vacio son invalidos
+	require.PanicsWithValue(t, "El deque esta vacio", func() { deque.VerFrente() })
+	require.PanicsWithValue(t, "El deque esta vacio", func() { deque.VerFinal() })
+	require.PanicsWithValue(t, "El deque esta vacio", func() { deque.BorrarFrente() })
+	require.PanicsWithValue(t, "El deque esta vacio", func() { deque.BorrarFinal() })
+}
+
+func TestDequeComoCola(t *testing.T) {
+	deque := TDADeque.CrearDeque[int]()
+
+	// Insertando al final y borrando del frente se comporta como una cola
+	deque.InsertarFinal(5)
+	deque.InsertarFinal(10)
+	deque.InsertarFinal(15)
+	require.Equal(t, 5, deque.VerFrente(), "El frente deberia de ser 5")
+	require.Equal(t, 15, deque.VerFinal(), "El final deberia de ser 15")
+	require.Equal(t, 5, deque.BorrarFrente(), "El elemento borrado deberia ser 5")
+	require.Equal(t, 10, deque.BorrarFrente(), "El elemento borrado deberia ser 10")
+	require.Equal(t, 15, deque.BorrarFrente(), "El elemento borrado deberia ser 15")
+	require.True(t, deque.EstaVacia(), "El deque deberia de estar vacio despues de borrar todos los elementos")
+}
+
+func TestDequeComoPila(t *testing.T) {
+	deque := TDADeque.CrearDeque[string]()
+
+	// Insertando y borrando del mismo extremo se comporta como una pila
+	deque.InsertarFrente("a")
+	deque.InsertarFrente("b")
+	deque.InsertarFrente("c")
+	require.Equal(t, "c", deque.VerFrente(), "El frente deberia de ser 'c'")
+	require.Equal(t, "a", deque.VerFinal(), "El final deberia de ser 'a'")
+	require.Equal(t, "c", deque.BorrarFrente(), "El elemento borrado deberia ser 'c'")
+	require.Equal(t, "b", deque.BorrarFrente(), "El elemento borrado deberia ser 'b'")
+	require.Equal(t, "a", deque.BorrarFrente(), "El elemento borrado deberia ser 'a'")
+
+	deque.InsertarFinal("x")
+	deque.InsertarFinal("y")
+	require.Equal(t, "y", deque.BorrarFinal(), "El elemento borrado deberia ser 'y'")
+	require.Equal(t, "x", deque.BorrarFinal(), "El elemento borrado deberia ser 'x'")
+	require.True(t, deque.EstaVacia(), "El deque deberia de estar vacio despues de borrar todos los elementos")
+}
+
+func TestDequeCondicionesBordes(t *testing.T) {
+	deque := TDADeque.CrearDeque[int]()
+	// Insertar por un extremo y borrar por el otro hasta vaciarlo, el deque se comporta como recien creado
+	deque.InsertarFrente(1)
+	deque.InsertarFrente(2)
+	require.Equal(t, 1, deque.BorrarFinal())
+	require.Equal(t, 2, deque.BorrarFinal())
+	require.True(t, deque.EstaVacia(), "El deque deberia de estar vacio")
+	require.PanicsWithValue(t, "El deque esta vacio", func() { deque.BorrarFrente() })
+	require.PanicsWithValue(t, "El deque esta vacio", func() { deque.VerFinal() })
+
+	// Con un unico elemento, el frente y el final coinciden
+	deque.InsertarFinal(7)
+	require.Equal(t, 7, deque.VerFrente())
+	require.Equal(t, 7, deque.VerFinal())
+}
+
+func TestDequeIterador(t *testing.T) {
+	deque := TDADeque.CrearDeque[int]()
+	for i := 3; i <= 5; i++ {
+		deque.InsertarFinal(i)
+	}
+	for i := 2; i >= 0; i-- {
+		deque.InsertarFrente(i)
+	}
+
+	iter := deque.Iterador()
+	for i := 0; i <= 5; i++ {
+		require.True(t, iter.HaySiguiente(), "El iterador deberia tener siguiente")
+		require.Equal(t, i, iter.VerActual(), "El valor actual deberia de ser %d", i)
+		iter.Siguiente()
+	}
+	require.False(t, iter.HaySiguiente(), "El iterador deberia haber terminado")
+	require.PanicsWithValue(t, "El iterador termino de iterar", func() { iter.VerActual() })
+	require.PanicsWithValue(t, "El iterador termino de iterar", func() { iter.Siguiente() })
+
+	recorridos := []int{}
+	for elem := range deque.Todos() {
+		if elem == 3 {
+			break
+		}
+		recorridos = append(recorridos, elem)
+	}
+	require.Equal(t, []int{0, 1, 2}, recorridos, "Se espera que el break corte la iteracion")
+}
+
+func TestDequeVolumen(t *testing.T) {
+	const cantidad = 10000
+	deque := TDADeque.CrearDeque[int]()
+
+	// Intercalamos los extremos para que los elementos den la vuelta al arreglo mientras se redimensiona
+	for i := 1; i <= cantidad; i++ {
+		deque.InsertarFrente(-i)
+		deque.InsertarFinal(i)
+	}
+	require.Equal(t, 2*cantidad, deque.Cantidad())
+	for i := cantidad; i > 0; i-- {
+		require.Equal(t, -i, deque.BorrarFrente(), "El frente deberia ser %d", -i)
+		require.Equal(t, i, deque.BorrarFinal(), "El final deberia ser %d", i)
+	}
+	require.True(t, deque.EstaVacia(), "El deque deberia estar vacio despues de borrar todos los elementos")
+}
+
+func TestDequeMaximoEnVentana(t *testing.T) {
+	// Cola monotona: el deque guarda indices con valores decrecientes, y el frente es el maximo de la ventana
+	pedidosPorSegundo := []int{3, 1, 4, 1, 5, 9, 2, 6, 5, 3, 5}
+	const ventana = 3
+	esperados := []int{4, 4, 5, 9, 9, 9, 6, 6, 5}
+
+	indices := TDADeque.CrearDeque[int]()
+	maximos := []int{}
+	for i, pedidos := range pedidosPorSegundo {
+		for !indices.EstaVacia() && pedidosPorSegundo[indices.VerFinal()] <= pedidos {
+			indices.BorrarFinal()
+		}
+		indices.InsertarFinal(i)
+		if indices.VerFrente() <= i-ventana {
+			indices.BorrarFrente()
+		}
+		if i >= ventana-1 {
+			maximos = append(maximos, pedidosPorSegundo[indices.VerFrente()])
+		}
+	}
+	require.Equal(t, esperados, maximos)
+}
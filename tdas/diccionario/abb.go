@@ -1,6 +1,9 @@
 package diccionario
 
-import TDAPila "tdas/pila"
+import (
+	"iter"
+	TDAPila "tdas/pila"
+)
 
 type funcion_cmp[K comparable] func(K, K) int
 
@@ -12,9 +15,10 @@ type nodoAbb[K comparable, V any] struct {
 }
 
 type abb[K comparable, V any] struct {
-	raiz     *nodoAbb[K, V]
-	cantidad int
-	cmp      funcion_cmp[K]
+	raiz           *nodoAbb[K, V]
+	cantidad       int
+	modificaciones int
+	cmp            funcion_cmp[K]
 }
 
 // PRE: tipo debe de ser una cadena valida
@@ -26,6 +30,15 @@ func mensajesPanic(tipo string) string {
 	if tipo == "iterador" {
 		return "El iterador termino de iterar"
 	}
+	if tipo == "modificado" {
+		return "El diccionario fue modificado durante la iteracion"
+	}
+	if tipo == "largos" {
+		return "Las claves y los valores deben tener el mismo largo"
+	}
+	if tipo == "desordenado" {
+		return "Las claves deben estar ordenadas y sin repetir"
+	}
 	return "Error desconocido"
 }
 
@@ -33,6 +46,81 @@ func CrearABB[K comparable, V any](funcion_cmp func(K, K) int) DiccionarioOrdena
 	return &abb[K, V]{raiz: nil, cantidad: 0, cmp: funcion_cmp}
 }
 
+// CrearABBDesdeOrdenado crea un ABB balanceado en O(n) a partir de claves ordenadas de forma estrictamente creciente
+// segun funcion_cmp, donde valores[i] es el dato de claves[i]. Entra en panico si los largos no coinciden o si
+// las claves no estan ordenadas
+func CrearABBDesdeOrdenado[K comparable, V any](claves []K, valores []V, funcion_cmp func(K, K) int) DiccionarioOrdenado[K, V] {
+	if len(claves) != len(valores) {
+		panic(mensajesPanic("largos"))
+	}
+	for i := 1; i < len(claves); i++ {
+		if funcion_cmp(claves[i-1], claves[i]) >= 0 {
+			panic(mensajesPanic("desordenado"))
+		}
+	}
+	return &abb[K, V]{raiz: construirBalanceado(claves, valores), cantidad: len(claves), cmp: funcion_cmp}
+}
+
+// PRE: claves debe de estar ordenado y tener el mismo largo que valores
+// POST: retorna la raiz de un arbol balanceado con los pares, tomando como raiz el par del medio
+func construirBalanceado[K comparable, V any](claves []K, valores []V) *nodoAbb[K, V] {
+	if len(claves) == 0 {
+		return nil
+	}
+	medio := len(claves) / 2
+	return &nodoAbb[K, V]{
+		clave:     claves[medio],
+		valor:     valores[medio],
+		izquierdo: construirBalanceado(claves[:medio], valores[:medio]),
+		derecho:   construirBalanceado(claves[medio+1:], valores[medio+1:]),
+	}
+}
+
+// PRE: dicc debe de existir
+// POST: retorna las claves y los valores del diccionario en orden
+func paresEnOrden[K comparable, V any](dicc DiccionarioOrdenado[K, V]) ([]K, []V) {
+	claves := make([]K, 0, dicc.Cantidad())
+	valores := make([]V, 0, dicc.Cantidad())
+	dicc.Iterar(func(clave K, valor V) bool {
+		claves = append(claves, clave)
+		valores = append(valores, valor)
+		return true
+	})
+	return claves, valores
+}
+
+// Se intercalan los pares de ambos diccionarios como en el merge de merge sort y se reconstruye el arbol
+// balanceado, por lo que ademas de unir, el ABB queda con altura logaritmica
+func (a *abb[K, V]) Unir(otro DiccionarioOrdenado[K, V]) {
+	propias, propios := paresEnOrden[K, V](a)
+	ajenas, ajenos := paresEnOrden(otro)
+	claves, valores := make([]K, 0, len(propias)+len(ajenas)), make([]V, 0, len(propias)+len(ajenas))
+	i, j := 0, 0
+	for i < len(propias) || j < len(ajenas) {
+		var comparacion int
+		if i == len(propias) {
+			comparacion = 1
+		} else if j == len(ajenas) {
+			comparacion = -1
+		} else {
+			comparacion = a.cmp(propias[i], ajenas[j])
+		}
+		if comparacion < 0 {
+			claves, valores = append(claves, propias[i]), append(valores, propios[i])
+			i++
+			continue
+		}
+		if comparacion == 0 {
+			i++
+		}
+		claves, valores = append(claves, ajenas[j]), append(valores, ajenos[j])
+		j++
+	}
+	a.raiz = construirBalanceado(claves, valores)
+	a.cantidad = len(claves)
+	a.modificaciones++
+}
+
 func (a *abb[K, V]) Iterar(visitar func(clave K, valor V) bool) {
 	a.iterarRecursivo(a.raiz, visitar)
 }
@@ -90,6 +178,7 @@ func (a *abb[K, V]) Cantidad() int {
 func (a *abb[K, V]) insertarNodo(nodoActual *nodoAbb[K, V], clave K, valor V) *nodoAbb[K, V] {
 	if nodoActual == nil {
 		a.cantidad++
+		a.modificaciones++
 		return &nodoAbb[K, V]{clave: clave, valor: valor}
 	}
 	resultado := a.cmp(clave, nodoActual.clave)
@@ -141,6 +230,7 @@ func (a *abb[K, V]) nodoConHijoUnico(nodo *nodoAbb[K, V]) bool {
 // POST: el nodo se elimina y retorna nil y el valor del nodo eliminado
 func (a *abb[K, V]) eliminarHoja(nodo *nodoAbb[K, V]) (*nodoAbb[K, V], V) {
 	a.cantidad--
+	a.modificaciones++
 	return nil, nodo.valor
 }
 
@@ -148,6 +238,7 @@ func (a *abb[K, V]) eliminarHoja(nodo *nodoAbb[K, V]) (*nodoAbb[K, V], V) {
 // POST: se elimina el nodo y se retorna su unico hijo y el valor del nodo eliminado
 func (a *abb[K, V]) eliminarNodoConUnHijo(nodo *nodoAbb[K, V]) (*nodoAbb[K, V], V) {
 	a.cantidad--
+	a.modificaciones++
 	if nodo.izquierdo == nil {
 		return nodo.derecho, nodo.valor
 	}
@@ -201,6 +292,16 @@ func (a *abb[K, V]) IterarRango(desde *K, hasta *K, visitar func(clave K, valor
 	a.iterarRangoRecursivo(a.raiz, desde, hasta, visitar)
 }
 
+func (a *abb[K, V]) Todos() iter.Seq2[K, V] {
+	return a.TodosRango(nil, nil)
+}
+
+func (a *abb[K, V]) TodosRango(desde *K, hasta *K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		a.IterarRango(desde, hasta, fallarSiSeModifica(&a.modificaciones, yield))
+	}
+}
+
 // PRE: Nodo de tipo *nodoAbb[K, V] declarado, variables desde y hasta de tipo comparativo inicializadas
 //
 //	y visitar de tipo funcion func(clave K, valor V) bool) bool inicializada
@@ -226,10 +327,12 @@ func (a *abb[K, V]) iterarRangoRecursivo(nodo *nodoAbb[K, V], desde *K, hasta *K
 }
 
 type iteradorABB[K comparable, V any] struct {
-	pila  TDAPila.Pila[*nodoAbb[K, V]]
-	desde *K
-	hasta *K
-	cmp   funcion_cmp[K]
+	arbol          *abb[K, V]
+	pila           TDAPila.Pila[*nodoAbb[K, V]]
+	desde          *K
+	hasta          *K
+	cmp            funcion_cmp[K]
+	modificaciones int
 }
 
 // Reutiliza IteradorRango con límites `nil` para iterar todo el ABB
@@ -237,7 +340,16 @@ func (a *abb[K, V]) Iterador() IterDiccionario[K, V] {
 	return a.IteradorRango(nil, nil)
 }
 
+// PRE: el iterador debe de haber sido creado a partir de un ABB existente
+// POST: entra en panico si el ABB fue modificado por fuera del iterador desde que este fue creado
+func (iter *iteradorABB[K, V]) verificarModificaciones() {
+	if iter.modificaciones != iter.arbol.modificaciones {
+		panic(mensajesPanic("modificado"))
+	}
+}
+
 func (iter *iteradorABB[K, V]) HaySiguiente() bool {
+	iter.verificarModificaciones()
 	if iter.pila.EstaVacia() {
 		return false
 	}
@@ -264,13 +376,26 @@ func (iter *iteradorABB[K, V]) Siguiente() {
 	iter.apilarRango(nodo.derecho)
 }
 
+// Al borrar, el ABB puede reacomodar nodos que estaban en la pila, por lo que se vuelve a apilar
+// el camino hacia la primera clave mayor a la borrada
+func (iter *iteradorABB[K, V]) Borrar() (K, V) {
+	clave, _ := iter.VerActual()
+	valor := iter.arbol.Borrar(clave)
+	iter.modificaciones = iter.arbol.modificaciones
+	iter.pila = TDAPila.CrearPilaDinamica[*nodoAbb[K, V]]()
+	iter.apilarMayores(iter.arbol.raiz, clave)
+	return clave, valor
+}
+
 // Reemplazamos el uso del iterador estándar y aplicamos el rango
 func (a *abb[K, V]) IteradorRango(desde *K, hasta *K) IterDiccionario[K, V] {
 	iter := &iteradorABB[K, V]{
-		pila:  TDAPila.CrearPilaDinamica[*nodoAbb[K, V]](),
-		desde: desde,
-		hasta: hasta,
-		cmp:   a.cmp,
+		arbol:          a,
+		pila:           TDAPila.CrearPilaDinamica[*nodoAbb[K, V]](),
+		desde:          desde,
+		hasta:          hasta,
+		cmp:            a.cmp,
+		modificaciones: a.modificaciones,
 	}
 
 	// Apilamos los nodos comenzando desde 'desde' (si es necesario)
@@ -291,3 +416,16 @@ func (iter *iteradorABB[K, V]) apilarRango(nodoActual *nodoAbb[K, V]) {
 		}
 	}
 }
+
+// PRE: Nodo de tipo *nodoAbb[K, V] declarado y clave de tipo comparativo inicializada
+// POST: apila los nodos cuya clave es estrictamente mayor a la clave dada, dejando en el tope al menor de ellos
+func (iter *iteradorABB[K, V]) apilarMayores(nodoActual *nodoAbb[K, V], clave K) {
+	for nodoActual != nil {
+		if iter.cmp(nodoActual.clave, clave) > 0 {
+			iter.pila.Apilar(nodoActual)
+			nodoActual = nodoActual.izquierdo
+		} else {
+			nodoActual = nodoActual.derecho
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package diccionario
 
+import "iter"
+
 type Diccionario[K comparable, V any] interface {
 
 	// Guardar guarda el par clave-dato en el Diccionario. Si la clave ya se encontraba, se actualiza el dato asociado
@@ -25,8 +27,16 @@ type Diccionario[K comparable, V any] interface {
 
 	// Iterador devuelve un IterDiccionario para este Diccionario
 	Iterador() IterDiccionario[K, V]
+
+	// Todos devuelve un iter.Seq2 que recorre los pares clave-dato del diccionario, para usar con
+	// 'for clave, dato := range dicc.Todos()'. Cortar el ciclo con break corta la iteracion. Como con el iterador
+	// externo, si dentro del ciclo se guarda una clave nueva o se borra una existente, al seguir iterando entra en
+	// pánico con mensaje 'El diccionario fue modificado durante la iteracion'
+	Todos() iter.Seq2[K, V]
 }
 
+// Si el diccionario se modifica por fuera del iterador (guardando una clave nueva o borrando una existente),
+// las primitivas del iterador deben entrar en pánico con mensaje 'El diccionario fue modificado durante la iteracion'
 type IterDiccionario[K comparable, V any] interface {
 
 	// HaySiguiente devuelve si hay más datos para ver. Esto es, si en el lugar donde se encuentra parado
@@ -40,4 +50,9 @@ type IterDiccionario[K comparable, V any] interface {
 	// Siguiente si HaySiguiente avanza al siguiente elemento en el diccionario. Si no HaySiguiente, entonces debe
 	// entrar en pánico con mensaje 'El iterador termino de iterar'
 	Siguiente()
+
+	// Borrar elimina del diccionario el elemento actual, devolviendo su clave y su dato, y deja al iterador
+	// posicionado en el elemento siguiente. Si no HaySiguiente, debe entrar en pánico con mensaje
+	// 'El iterador termino de iterar'
+	Borrar() (K, V)
 }
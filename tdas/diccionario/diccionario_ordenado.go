@@ -1,8 +1,20 @@
 package diccionario
 
+import "iter"
+
 type DiccionarioOrdenado[K comparable, V any] interface {
 	Diccionario[K, V]
 
 	IterarRango(desde *K, hasta *K, visitar func(clave K, dato V) bool)
 	IteradorRango(desde *K, hasta *K) IterDiccionario[K, V]
+
+	// TodosRango devuelve un iter.Seq2 que recorre en orden los pares clave-dato cuyas claves estan entre desde y
+	// hasta (inclusive). Un limite en nil indica que no hay cota de ese lado, y si desde es mayor a hasta el rango
+	// esta vacio. Al igual que Todos, entra en pánico si el diccionario se modifica dentro del ciclo
+	TodosRango(desde *K, hasta *K) iter.Seq2[K, V]
+
+	// Unir agrega al diccionario todos los pares clave-dato de otro, en tiempo lineal en la cantidad total de
+	// claves. Si una clave esta en ambos, queda el dato de otro. Ambos diccionarios deben de estar ordenados con
+	// el mismo criterio. otro no se modifica
+	Unir(otro DiccionarioOrdenado[K, V])
 }
@@ -1,6 +1,7 @@
 package diccionario_test
 
 import (
+	"fmt"
 	"testing"
 
 	TDADiccionario "tdas/diccionario"
@@ -558,3 +559,244 @@ func TestIteradorABBVacio(t *testing.T) {
 	iterRango := abb.IteradorRango(&desde, &hasta)
 	require.False(t, iterRango.HaySiguiente(), "Se espera que no haya un siguiente dentro de un iterador vacio")
 }
+
+func TestIteradorABBDetectaModificaciones(t *testing.T) {
+	abb := TDADiccionario.CrearABB[int, int](cmpInt)
+	for _, clave := range []int{50, 25, 75, 10, 30} {
+		abb.Guardar(clave, clave)
+	}
+	iter := abb.Iterador()
+	abb.Guardar(25, 0)
+	require.True(t, iter.HaySiguiente(), "Actualizar el dato de una clave existente no invalida al iterador")
+
+	abb.Guardar(60, 60)
+	require.PanicsWithValue(t, "El diccionario fue modificado durante la iteracion", func() { iter.HaySiguiente() })
+	require.PanicsWithValue(t, "El diccionario fue modificado durante la iteracion", func() { iter.VerActual() })
+	require.PanicsWithValue(t, "El diccionario fue modificado durante la iteracion", func() { iter.Siguiente() })
+
+	desde, hasta := 20, 60
+	iterRango := abb.IteradorRango(&desde, &hasta)
+	abb.Borrar(10)
+	require.PanicsWithValue(t, "El diccionario fue modificado durante la iteracion", func() { iterRango.HaySiguiente() })
+}
+
+func TestIteradorABBBorrar(t *testing.T) {
+	abb := TDADiccionario.CrearABB[int, int](cmpInt)
+	claves := rand.Perm(200)
+	for _, clave := range claves {
+		abb.Guardar(clave, clave*2)
+	}
+
+	// Borramos los multiplos de 3 dentro del rango, verificando que el resto se sigue recorriendo en orden
+	desde, hasta := 40, 160
+	esperada := desde
+	for iter := abb.IteradorRango(&desde, &hasta); iter.HaySiguiente(); {
+		clave, valor := iter.VerActual()
+		require.Equal(t, esperada, clave, "El iterador debe seguir en orden luego de borrar")
+		require.Equal(t, clave*2, valor)
+		if clave%3 == 0 {
+			borrada, dato := iter.Borrar()
+			require.Equal(t, clave, borrada)
+			require.Equal(t, clave*2, dato)
+		} else {
+			iter.Siguiente()
+		}
+		esperada++
+	}
+	require.Equal(t, hasta+1, esperada)
+	require.Equal(t, 200-40, abb.Cantidad())
+	for i := 0; i < 200; i++ {
+		require.Equal(t, i < desde || i > hasta || i%3 != 0, abb.Pertenece(i))
+	}
+
+	iter := abb.Iterador()
+	for iter.HaySiguiente() {
+		iter.Borrar()
+	}
+	require.Equal(t, 0, abb.Cantidad())
+	require.PanicsWithValue(t, "El iterador termino de iterar", func() { iter.Borrar() })
+}
+
+func TestRangeABB(t *testing.T) {
+	abb := TDADiccionario.CrearABB[int, string](cmpInt)
+	for _, clave := range []int{50, 25, 75, 10, 30, 60, 90} {
+		abb.Guardar(clave, fmt.Sprintf("%d", clave))
+	}
+
+	claves := []int{}
+	for clave, valor := range abb.Todos() {
+		require.Equal(t, fmt.Sprintf("%d", clave), valor)
+		claves = append(claves, clave)
+	}
+	require.Equal(t, []int{10, 25, 30, 50, 60, 75, 90}, claves, "Se espera recorrer el ABB en orden")
+
+	desde, hasta := 25, 75
+	claves = []int{}
+	for clave := range abb.TodosRango(&desde, &hasta) {
+		if clave == 60 {
+			break
+		}
+		claves = append(claves, clave)
+	}
+	require.Equal(t, []int{25, 30, 50}, claves, "Se espera recorrer el rango en orden hasta el break")
+
+	claves = []int{}
+	for clave := range abb.TodosRango(nil, &desde) {
+		claves = append(claves, clave)
+	}
+	require.Equal(t, []int{10, 25}, claves, "Un limite en nil no acota el rango")
+
+	for _, rango := range [][2]int{{26, 29}, {91, 100}, {0, 9}, {75, 25}} {
+		for clave := range abb.TodosRango(&rango[0], &rango[1]) {
+			require.Fail(t, "No se espera recorrer ninguna clave", "rango %v, clave %d", rango, clave)
+		}
+	}
+	for range TDADiccionario.CrearABB[int, string](cmpInt).Todos() {
+		require.Fail(t, "No se espera recorrer ninguna clave de un ABB vacio")
+	}
+}
+
+func TestRangeABBDetectaModificaciones(t *testing.T) {
+	abb := TDADiccionario.CrearABB[int, int](cmpInt)
+	for _, clave := range []int{50, 25, 75, 10, 30} {
+		abb.Guardar(clave, clave)
+	}
+	recorridas := 0
+	for clave := range abb.Todos() {
+		abb.Guardar(clave, 0)
+		recorridas++
+	}
+	require.Equal(t, 5, recorridas, "Actualizar el dato de una clave existente no corta el ciclo")
+
+	require.PanicsWithValue(t, "El diccionario fue modificado durante la iteracion", func() {
+		for clave := range abb.Todos() {
+			abb.Guardar(clave+1, 0)
+		}
+	})
+	desde, hasta := 20, 60
+	require.PanicsWithValue(t, "El diccionario fue modificado durante la iteracion", func() {
+		for clave := range abb.TodosRango(&desde, &hasta) {
+			abb.Borrar(clave)
+		}
+	})
+
+	// Si el ciclo se corta justo despues de modificar el ABB, no se vuelve a iterar y no hay panico
+	for clave := range abb.TodosRango(&desde, &hasta) {
+		abb.Borrar(clave)
+		break
+	}
+	require.False(t, abb.Pertenece(30))
+}
+
+func TestCrearABBDesdeOrdenado(t *testing.T) {
+	claves := []int{1, 3, 5, 7, 9, 11}
+	valores := []string{"a", "b", "c", "d", "e", "f"}
+	abb := TDADiccionario.CrearABBDesdeOrdenado(claves, valores, cmpInt)
+	require.Equal(t, len(claves), abb.Cantidad())
+	for i, clave := range claves {
+		require.Equal(t, valores[i], abb.Obtener(clave))
+	}
+	require.False(t, abb.Pertenece(4))
+
+	obtenidas := []int{}
+	for clave := range abb.Todos() {
+		obtenidas = append(obtenidas, clave)
+	}
+	require.Equal(t, claves, obtenidas)
+
+	// El arbol construido sigue siendo un ABB comun
+	abb.Guardar(4, "x")
+	require.Equal(t, "c", abb.Borrar(5))
+	require.Equal(t, "x", abb.Obtener(4))
+
+	vacio := TDADiccionario.CrearABBDesdeOrdenado([]int{}, []string{}, cmpInt)
+	require.Equal(t, 0, vacio.Cantidad())
+}
+
+func TestCrearABBDesdeOrdenadoInvalido(t *testing.T) {
+	require.PanicsWithValue(t, "Las claves y los valores deben tener el mismo largo", func() {
+		TDADiccionario.CrearABBDesdeOrdenado([]int{1, 2}, []string{"a"}, cmpInt)
+	})
+	require.PanicsWithValue(t, "Las claves deben estar ordenadas y sin repetir", func() {
+		TDADiccionario.CrearABBDesdeOrdenado([]int{1, 3, 2}, []string{"a", "b", "c"}, cmpInt)
+	})
+	require.PanicsWithValue(t, "Las claves deben estar ordenadas y sin repetir", func() {
+		TDADiccionario.CrearABBDesdeOrdenado([]int{1, 1}, []string{"a", "b"}, cmpInt)
+	})
+}
+
+func TestCrearABBDesdeOrdenadoVolumen(t *testing.T) {
+	// Insertar claves ordenadas una a una degeneraria el ABB en una lista, construirlo de una vez lo deja balanceado
+	largo := 1000000
+	claves := make([]int, largo)
+	valores := make([]int, largo)
+	for i := range claves {
+		claves[i], valores[i] = i*2, i
+	}
+	abb := TDADiccionario.CrearABBDesdeOrdenado(claves, valores, cmpInt)
+	for i := 0; i < largo; i += 7 {
+		require.Equal(t, i, abb.Obtener(i*2))
+		require.False(t, abb.Pertenece(i*2+1))
+	}
+}
+
+func TestUnir(t *testing.T) {
+	abb := TDADiccionario.CrearABB[int, string](cmpInt)
+	otro := TDADiccionario.CrearABB[int, string](cmpInt)
+	for _, clave := range []int{10, 20, 30, 40} {
+		abb.Guardar(clave, "propio")
+	}
+	for _, clave := range []int{5, 20, 35, 40, 50} {
+		otro.Guardar(clave, "otro")
+	}
+
+	abb.Unir(otro)
+	require.Equal(t, 7, abb.Cantidad())
+	claves := []int{}
+	for clave, valor := range abb.Todos() {
+		claves = append(claves, clave)
+		if clave == 20 || clave == 40 {
+			require.Equal(t, "otro", valor, "Ante claves repetidas queda el dato de otro")
+		}
+	}
+	require.Equal(t, []int{5, 10, 20, 30, 35, 40, 50}, claves)
+	require.Equal(t, 5, otro.Cantidad(), "Unir no deberia modificar a otro")
+
+	// Unir con un diccionario vacio o consigo mismo no cambia las claves
+	abb.Unir(TDADiccionario.CrearABB[int, string](cmpInt))
+	abb.Unir(abb)
+	require.Equal(t, 7, abb.Cantidad())
+	vacio := TDADiccionario.CrearABB[int, string](cmpInt)
+	vacio.Unir(abb)
+	require.Equal(t, 7, vacio.Cantidad())
+	require.Equal(t, "propio", vacio.Obtener(10))
+}
+
+func TestUnirContraGuardar(t *testing.T) {
+	generador := rand.New(rand.NewSource(37))
+	abb := TDADiccionario.CrearABB[int, int](cmpInt)
+	esperado := TDADiccionario.CrearABB[int, int](cmpInt)
+	for ronda := 0; ronda < 20; ronda++ {
+		otro := TDADiccionario.CrearABB[int, int](cmpInt)
+		for i := 0; i < 200; i++ {
+			clave := generador.Intn(2000)
+			otro.Guardar(clave, ronda)
+			esperado.Guardar(clave, ronda)
+		}
+		abb.Unir(otro)
+		require.Equal(t, esperado.Cantidad(), abb.Cantidad())
+		for clave, valor := range esperado.Todos() {
+			require.Equal(t, valor, abb.Obtener(clave))
+		}
+	}
+}
+
+func TestUnirInvalidaIteradores(t *testing.T) {
+	abb := TDADiccionario.CrearABB[int, int](cmpInt)
+	abb.Guardar(1, 1)
+	otro := TDADiccionario.CrearABB[int, int](cmpInt)
+	otro.Guardar(2, 2)
+	iter := abb.Iterador()
+	abb.Unir(otro)
+	require.PanicsWithValue(t, "El diccionario fue modificado durante la iteracion", func() { iter.HaySiguiente() })
+}
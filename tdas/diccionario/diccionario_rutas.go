@@ -0,0 +1,33 @@
+package diccionario
+
+import "iter"
+
+// DiccionarioRutas es un Diccionario cuyas claves son rutas separadas por '/', como "/album/presto". Las claves
+// se organizan por segmentos, de modo que se pueden recorrer todas las que estan bajo un mismo prefijo. Un prefijo
+// se compara segmento a segmento: "/album" abarca a "/album" y a "/album/presto", pero no a "/albums".
+type DiccionarioRutas[V any] interface {
+	Diccionario[string, V]
+
+	// CantidadPrefijo devuelve la cantidad de claves que se encuentran bajo el prefijo, incluyendo al prefijo
+	// mismo si es una clave.
+	CantidadPrefijo(prefijo string) int
+
+	// IterarPrefijo recorre en preorden los pares clave-dato cuyas claves se encuentran bajo el prefijo, aplicando
+	// la función pasada por parámetro mientras devuelva true. Los segmentos de un mismo nivel se recorren en orden.
+	IterarPrefijo(prefijo string, visitar func(clave string, dato V) bool)
+
+	// TodosPrefijo devuelve un iter.Seq2 que recorre los mismos pares que IterarPrefijo. Al igual que Todos, entra
+	// en pánico si el diccionario se modifica dentro del ciclo.
+	TodosPrefijo(prefijo string) iter.Seq2[string, V]
+
+	// IterarNodos recorre en preorden las rutas intermedias y finales que tienen alguna clave bajo ellas, hasta la
+	// profundidad indicada (la cantidad de '/' de la ruta), aplicando visitar mientras devuelva true. Cada ruta
+	// visitada se puede usar como prefijo para obtener sus claves.
+	IterarNodos(profundidad int, visitar func(ruta string, profundidad int) bool)
+
+	// IterarNodosAcumulados recorre las mismas rutas que IterarNodos, pasando ademas el total de los datos de todas
+	// las claves bajo cada ruta, incluidas las que superan la profundidad. Los totales se calculan en un unico
+	// recorrido en postorden, combinando los datos con sumar a partir del valor cero de V, por lo que sumar debe de
+	// ser asociativa.
+	IterarNodosAcumulados(profundidad int, sumar func(acumulado, dato V) V, visitar func(ruta string, profundidad int, total V) bool)
+}
@@ -0,0 +1,235 @@
+package diccionario_test
+
+import (
+	"fmt"
+	"strings"
+	TDADiccionario "tdas/diccionario"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var RECURSOS = []string{"/album/presto", "/album/movingpictures", "/album/clockworkangels", "/", "/albums",
+	"/album/", "/album", "/projects/xdotool/", "/projects/xdotool/xdotool.xhtml"}
+
+// PRE:
+// POST: crea un DiccionarioRutas con los RECURSOS, guardando como dato la posicion de cada uno
+func crearDiccionarioRecursos() TDADiccionario.DiccionarioRutas[int] {
+	dic := TDADiccionario.CrearDiccionarioRutas[int]()
+	for i, recurso := range RECURSOS {
+		dic.Guardar(recurso, i)
+	}
+	return dic
+}
+
+// PRE:
+// POST: devuelve las claves bajo el prefijo, en el orden en que se recorren
+func clavesBajo(dic TDADiccionario.DiccionarioRutas[int], prefijo string) []string {
+	claves := []string{}
+	for clave := range dic.TodosPrefijo(prefijo) {
+		claves = append(claves, clave)
+	}
+	return claves
+}
+
+func TestDiccionarioRutasVacio(t *testing.T) {
+	dic := TDADiccionario.CrearDiccionarioRutas[int]()
+	require.EqualValues(t, 0, dic.Cantidad())
+	require.False(t, dic.Pertenece("/"))
+	require.False(t, dic.Pertenece(""))
+	require.PanicsWithValue(t, "La clave no pertenece al diccionario", func() { dic.Obtener("/album") })
+	require.PanicsWithValue(t, "La clave no pertenece al diccionario", func() { dic.Borrar("/album") })
+	require.EqualValues(t, 0, dic.CantidadPrefijo("/"))
+	require.False(t, dic.Iterador().HaySiguiente())
+}
+
+func TestDiccionarioRutasGuardarYObtener(t *testing.T) {
+	dic := crearDiccionarioRecursos()
+	require.EqualValues(t, len(RECURSOS), dic.Cantidad())
+	for i, recurso := range RECURSOS {
+		require.True(t, dic.Pertenece(recurso), "%s deberia pertenecer", recurso)
+		require.EqualValues(t, i, dic.Obtener(recurso))
+	}
+
+	// Los segmentos intermedios no son claves si no se guardaron
+	require.False(t, dic.Pertenece("/projects"))
+	require.False(t, dic.Pertenece("/projects/xdotool"))
+	require.False(t, dic.Pertenece("/alb"))
+
+	// Guardar una clave existente actualiza el dato
+	dic.Guardar("/album/presto", 100)
+	require.EqualValues(t, 100, dic.Obtener("/album/presto"))
+	require.EqualValues(t, len(RECURSOS), dic.Cantidad())
+}
+
+func TestDiccionarioRutasPrefijos(t *testing.T) {
+	dic := crearDiccionarioRecursos()
+
+	// El prefijo se compara por segmentos y se recorre en preorden, con cada nivel ordenado
+	require.Equal(t, []string{"/album", "/album/", "/album/clockworkangels", "/album/movingpictures", "/album/presto"},
+		clavesBajo(dic, "/album"))
+	require.EqualValues(t, 5, dic.CantidadPrefijo("/album"))
+	require.Equal(t, []string{"/projects/xdotool/", "/projects/xdotool/xdotool.xhtml"}, clavesBajo(dic, "/projects"))
+	require.Empty(t, clavesBajo(dic, "/alb"), "Un prefijo que no coincide con un segmento no abarca claves")
+	require.EqualValues(t, 0, dic.CantidadPrefijo("/noexiste"))
+
+	// El prefijo vacio abarca a todas las rutas absolutas
+	require.Len(t, clavesBajo(dic, ""), len(RECURSOS))
+
+	recorridos := 0
+	dic.IterarPrefijo("/album", func(clave string, dato int) bool {
+		recorridos++
+		return recorridos < 2
+	})
+	require.EqualValues(t, 2, recorridos, "Se espera cortar la iteracion cuando visitar devuelve false")
+}
+
+func TestDiccionarioRutasIterarNodos(t *testing.T) {
+	dic := crearDiccionarioRecursos()
+
+	rutas := []string{}
+	dic.IterarNodos(1, func(ruta string, profundidad int) bool {
+		rutas = append(rutas, fmt.Sprintf("%d%s", profundidad, ruta))
+		return true
+	})
+	require.Equal(t, []string{"0", "1/", "1/album", "1/albums", "1/projects"}, rutas)
+
+	// Las rutas que quedan sin claves dejan de visitarse
+	dic.Borrar("/projects/xdotool/")
+	dic.Borrar("/projects/xdotool/xdotool.xhtml")
+	rutas = []string{}
+	dic.IterarNodos(3, func(ruta string, profundidad int) bool {
+		rutas = append(rutas, ruta)
+		return ruta != "/album/"
+	})
+	require.Equal(t, []string{"", "/", "/album", "/album/"}, rutas)
+}
+
+func TestDiccionarioRutasIterarNodosAcumulados(t *testing.T) {
+	dic := crearDiccionarioRecursos()
+	sumar := func(acumulado, dato int) int { return acumulado + dato }
+
+	// Recorre las mismas rutas que IterarNodos, con el mismo total que sumar las claves bajo cada una
+	esperadas := []string{}
+	dic.IterarNodos(2, func(ruta string, profundidad int) bool {
+		total := 0
+		for _, dato := range dic.TodosPrefijo(ruta) {
+			total += dato
+		}
+		esperadas = append(esperadas, fmt.Sprintf("%d%s=%d", profundidad, ruta, total))
+		return true
+	})
+	rutas := []string{}
+	dic.IterarNodosAcumulados(2, sumar, func(ruta string, profundidad, total int) bool {
+		rutas = append(rutas, fmt.Sprintf("%d%s=%d", profundidad, ruta, total))
+		return true
+	})
+	require.Equal(t, esperadas, rutas)
+	require.Contains(t, rutas, "1/projects=15", "El total incluye las claves que superan la profundidad")
+
+	rutas = []string{}
+	dic.IterarNodosAcumulados(1, sumar, func(ruta string, profundidad, total int) bool {
+		rutas = append(rutas, ruta)
+		return ruta != "/"
+	})
+	require.Equal(t, []string{"", "/"}, rutas, "Se deberia cortar la iteracion")
+}
+
+func TestDiccionarioRutasBorrar(t *testing.T) {
+	dic := crearDiccionarioRecursos()
+
+	require.EqualValues(t, 6, dic.Borrar("/album"))
+	require.False(t, dic.Pertenece("/album"))
+	require.True(t, dic.Pertenece("/album/presto"), "Borrar un prefijo no borra las claves bajo el")
+	require.EqualValues(t, 4, dic.CantidadPrefijo("/album"))
+	require.PanicsWithValue(t, "La clave no pertenece al diccionario", func() { dic.Borrar("/album") })
+	require.PanicsWithValue(t, "La clave no pertenece al diccionario", func() { dic.Borrar("/projects") })
+
+	for _, recurso := range RECURSOS {
+		if dic.Pertenece(recurso) {
+			dic.Borrar(recurso)
+		}
+	}
+	require.EqualValues(t, 0, dic.Cantidad())
+	require.Empty(t, clavesBajo(dic, ""))
+
+	// Luego de borrar todo, se puede volver a usar
+	dic.Guardar("/album/presto", 1)
+	require.EqualValues(t, 1, dic.Obtener("/album/presto"))
+}
+
+func TestDiccionarioRutasIterador(t *testing.T) {
+	dic := crearDiccionarioRecursos()
+
+	claves := []string{}
+	for iter := dic.Iterador(); iter.HaySiguiente(); iter.Siguiente() {
+		clave, dato := iter.VerActual()
+		require.EqualValues(t, dic.Obtener(clave), dato)
+		claves = append(claves, clave)
+	}
+	require.Equal(t, clavesBajo(dic, ""), claves, "El iterador externo recorre en el mismo orden que el interno")
+
+	iter := dic.Iterador()
+	dic.Guardar("/nuevo", 0)
+	require.PanicsWithValue(t, "El diccionario fue modificado durante la iteracion", func() { iter.HaySiguiente() })
+}
+
+func TestDiccionarioRutasIteradorBorrar(t *testing.T) {
+	dic := crearDiccionarioRecursos()
+
+	// Borramos con el iterador todo lo que esta bajo /album
+	for iter := dic.Iterador(); iter.HaySiguiente(); {
+		clave, _ := iter.VerActual()
+		if clave == "/album" || strings.HasPrefix(clave, "/album/") {
+			iter.Borrar()
+		} else {
+			iter.Siguiente()
+		}
+	}
+	require.EqualValues(t, len(RECURSOS)-5, dic.Cantidad())
+	require.EqualValues(t, 0, dic.CantidadPrefijo("/album"))
+	require.Equal(t, []string{"/", "/albums", "/projects/xdotool/", "/projects/xdotool/xdotool.xhtml"}, clavesBajo(dic, ""))
+
+	rutas := []string{}
+	dic.IterarNodos(1, func(ruta string, profundidad int) bool {
+		rutas = append(rutas, ruta)
+		return true
+	})
+	require.Equal(t, []string{"", "/", "/albums", "/projects"}, rutas, "Las rutas sin claves no se visitan")
+
+	// Se puede volver a guardar bajo una ruta que quedo vacia
+	dic.Guardar("/album/presto", 7)
+	require.EqualValues(t, 7, dic.Obtener("/album/presto"))
+	require.EqualValues(t, 1, dic.CantidadPrefijo("/album"))
+}
+
+func TestDiccionarioRutasRangeDetectaModificaciones(t *testing.T) {
+	dic := crearDiccionarioRecursos()
+	require.PanicsWithValue(t, "El diccionario fue modificado durante la iteracion", func() {
+		for clave := range dic.Todos() {
+			dic.Borrar(clave)
+		}
+	})
+	dic = crearDiccionarioRecursos()
+	require.PanicsWithValue(t, "El diccionario fue modificado durante la iteracion", func() {
+		for clave := range dic.TodosPrefijo("/album") {
+			dic.Guardar(clave+"/nuevo", 0)
+		}
+	})
+	require.Empty(t, clavesBajo(dic, "/noexiste"))
+}
+
+func TestDiccionarioRutasVolumen(t *testing.T) {
+	dic := TDADiccionario.CrearDiccionarioRutas[int]()
+	for i := 0; i < 10000; i++ {
+		dic.Guardar(fmt.Sprintf("/dir%d/sub%d/recurso%d", i%10, i%100, i), i)
+	}
+	require.EqualValues(t, 10000, dic.Cantidad())
+	require.EqualValues(t, 1000, dic.CantidadPrefijo("/dir3"))
+	require.EqualValues(t, 100, dic.CantidadPrefijo("/dir3/sub13"))
+	for i := 0; i < 10000; i += 2 {
+		require.EqualValues(t, i, dic.Borrar(fmt.Sprintf("/dir%d/sub%d/recurso%d", i%10, i%100, i)))
+	}
+	require.EqualValues(t, 5000, dic.Cantidad())
+	require.EqualValues(t, 0, dic.CantidadPrefijo("/dir4"), "Todos los recursos de /dir4 eran pares")
+}
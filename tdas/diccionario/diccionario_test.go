@@ -179,6 +179,29 @@ func TestReutlizacionDeBorrados(t *testing.T) {
 	require.EqualValues(t, "mundooo!", dic.Obtener(clave))
 }
 
+func TestBorradosNoCortanLaBusqueda(t *testing.T) {
+	t.Log("Prueba de caja blanca: si una clave se guardo despues de otra que luego se borra dentro del mismo " +
+		"sondeo, la clave tiene que seguir encontrandose y no debe duplicarse al volver a guardarla")
+	dic := TDADiccionario.CrearHash[int, int]()
+	for i := 0; i < 1000; i++ {
+		dic.Guardar(i, i)
+	}
+	for i := 0; i < 1000; i += 2 {
+		dic.Borrar(i)
+	}
+	for i := 1; i < 1000; i += 2 {
+		require.True(t, dic.Pertenece(i))
+		dic.Guardar(i, i*10)
+		require.EqualValues(t, i*10, dic.Obtener(i))
+	}
+	require.EqualValues(t, 500, dic.Cantidad())
+	for i := 1; i < 1000; i += 2 {
+		dic.Borrar(i)
+		require.False(t, dic.Pertenece(i))
+	}
+	require.EqualValues(t, 0, dic.Cantidad())
+}
+
 func TestConClavesNumericas(t *testing.T) {
 	t.Log("Valida que no solo funcione con strings")
 	dic := TDADiccionario.CrearHash[int, string]()
@@ -553,6 +576,113 @@ func TestPruebaIterarTrasBorrados(t *testing.T) {
 	require.False(t, iter.HaySiguiente())
 }
 
+func TestIteradorDetectaModificaciones(t *testing.T) {
+	t.Log("Si el diccionario se modifica por fuera del iterador, las primitivas del iterador entran en pánico")
+	dic := TDADiccionario.CrearHash[int, int]()
+	for i := 0; i < 10; i++ {
+		dic.Guardar(i, i)
+	}
+	iter := dic.Iterador()
+	require.True(t, iter.HaySiguiente())
+
+	dic.Guardar(5, 50)
+	require.True(t, iter.HaySiguiente(), "Actualizar el dato de una clave existente no invalida al iterador")
+
+	for i := 10; i < 100; i++ {
+		dic.Guardar(i, i)
+	}
+	require.PanicsWithValue(t, "El diccionario fue modificado durante la iteracion", func() { iter.HaySiguiente() })
+	require.PanicsWithValue(t, "El diccionario fue modificado durante la iteracion", func() { iter.VerActual() })
+	require.PanicsWithValue(t, "El diccionario fue modificado durante la iteracion", func() { iter.Siguiente() })
+
+	iter = dic.Iterador()
+	dic.Borrar(0)
+	require.PanicsWithValue(t, "El diccionario fue modificado durante la iteracion", func() { iter.HaySiguiente() })
+}
+
+func TestIteradorBorrar(t *testing.T) {
+	t.Log("Borra con el iterador todas las claves pares, y comprueba que el resto del diccionario no se altera")
+	dic := TDADiccionario.CrearHash[int, int]()
+	for i := 0; i < 100; i++ {
+		dic.Guardar(i, i*10)
+	}
+	visitados := 0
+	for iter := dic.Iterador(); iter.HaySiguiente(); {
+		clave, _ := iter.VerActual()
+		visitados++
+		if clave%2 == 0 {
+			borrada, dato := iter.Borrar()
+			require.EqualValues(t, clave, borrada)
+			require.EqualValues(t, clave*10, dato)
+		} else {
+			iter.Siguiente()
+		}
+	}
+	require.EqualValues(t, 100, visitados)
+	require.EqualValues(t, 50, dic.Cantidad())
+	for i := 0; i < 100; i++ {
+		require.EqualValues(t, i%2 != 0, dic.Pertenece(i))
+	}
+
+	iter := dic.Iterador()
+	for iter.HaySiguiente() {
+		iter.Borrar()
+	}
+	require.EqualValues(t, 0, dic.Cantidad())
+	require.PanicsWithValue(t, "El iterador termino de iterar", func() { iter.Borrar() })
+}
+
+func TestDiccionarioRange(t *testing.T) {
+	t.Log("Recorre el diccionario con range, y comprueba que un break corta la iteracion")
+	dic := TDADiccionario.CrearHash[string, int]()
+	claves := []string{"Gato", "Perro", "Vaca"}
+	for i, clave := range claves {
+		dic.Guardar(clave, i)
+	}
+	recorridos := 0
+	for clave, dato := range dic.Todos() {
+		require.EqualValues(t, buscar(clave, claves), dato)
+		recorridos++
+	}
+	require.EqualValues(t, 3, recorridos)
+
+	recorridos = 0
+	for range dic.Todos() {
+		recorridos++
+		if recorridos == 2 {
+			break
+		}
+	}
+	require.EqualValues(t, 2, recorridos)
+
+	for range TDADiccionario.CrearHash[string, int]().Todos() {
+		require.Fail(t, "No se espera recorrer ninguna clave de un diccionario vacio")
+	}
+}
+
+func TestDiccionarioRangeDetectaModificaciones(t *testing.T) {
+	t.Log("Si el diccionario se modifica dentro de un range, al seguir iterando entra en pánico como el iterador externo")
+	dic := TDADiccionario.CrearHash[int, int]()
+	for i := 0; i < 10; i++ {
+		dic.Guardar(i, i)
+	}
+	for clave := range dic.Todos() {
+		dic.Guardar(clave, clave*10)
+	}
+	require.EqualValues(t, 90, dic.Obtener(9), "Actualizar el dato de una clave existente no corta el ciclo")
+
+	require.PanicsWithValue(t, "El diccionario fue modificado durante la iteracion", func() {
+		for clave := range dic.Todos() {
+			dic.Guardar(clave+100, clave)
+		}
+	})
+	require.PanicsWithValue(t, "El diccionario fue modificado durante la iteracion", func() {
+		for clave := range dic.Todos() {
+			dic.Borrar(clave)
+		}
+	})
+}
+
 func ejecutarPruebasVolumenIterador(b *testing.B, n int) {
 	dic := TDADiccionario.CrearHash[string, *int]()
 
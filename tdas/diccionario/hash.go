@@ -21,7 +21,10 @@ package diccionario
 //    The XOR is an 8-bit operation that modifies only the lower 8-bits of the hash value.
 //    The hash value returned is a 64-bit unsigned integer.
 
-import "fmt"
+import (
+	"fmt"
+	"iter"
+)
 
 const (
 	capacidadInicial        = 16
@@ -35,9 +38,10 @@ const (
 )
 
 type hashCerrado[K comparable, V any] struct {
-	tabla    []elemento[K, V]
-	cantidad int
-	borrados int
+	tabla          []elemento[K, V]
+	cantidad       int
+	borrados       int
+	modificaciones int
 }
 
 type elemento[K comparable, V any] struct {
@@ -47,8 +51,9 @@ type elemento[K comparable, V any] struct {
 }
 
 type iteradorHash[K comparable, V any] struct {
-	hash     *hashCerrado[K, V]
-	posicion int
+	hash           *hashCerrado[K, V]
+	posicion       int
+	modificaciones int
 }
 
 func (h *hashCerrado[K, V]) inicializarTabla(capacidad int) {
@@ -83,14 +88,24 @@ func convertirABytes[K comparable](clave K) []byte {
 	return []byte(fmt.Sprintf("%v", clave))
 }
 
+// Si la clave no esta, devuelve el primer lugar borrado del sondeo (o el vacio que lo corta) para reutilizarlo.
+// Los borrados no cortan la busqueda, ya que la clave pudo haberse guardado despues de ellos.
 func (h *hashCerrado[K, V]) obtenerElemento(clave K) (int, *elemento[K, V]) {
 	indice := h.calcularHash(clave)
 	elem := &h.tabla[indice]
+	indiceLibre, libre := -1, (*elemento[K, V])(nil)
 
-	for elem.estado == OCUPADO && elem.clave != clave {
-		indice, elem = h.sondeoLineal(indice)
+	for elem.estado != VACIO && !(elem.estado == OCUPADO && elem.clave == clave) {
+		if elem.estado == BORRADO && libre == nil {
+			indiceLibre, libre = indice, elem
+		}
+		indice = (indice + 1) % len(h.tabla)
+		elem = &h.tabla[indice]
 	}
 
+	if elem.estado == VACIO && libre != nil {
+		return indiceLibre, libre
+	}
 	return indice, elem
 }
 
@@ -109,9 +124,28 @@ func mensajePanic(tipo string) string {
 	if tipo == "iterador" {
 		return "El iterador termino de iterar"
 	}
+	if tipo == "modificado" {
+		return "El diccionario fue modificado durante la iteracion"
+	}
 	return "Error desconocido"
 }
 
+// PRE: modificaciones debe de apuntar al contador de modificaciones del diccionario que se recorre
+// POST: retorna una funcion que aplica yield y, si el ciclo no se corto, entra en panico si el diccionario fue
+// modificado desde que se creo, para que Todos falle igual que el iterador externo
+func fallarSiSeModifica[K, V any](modificaciones *int, yield func(K, V) bool) func(K, V) bool {
+	inicial := *modificaciones
+	return func(clave K, dato V) bool {
+		if !yield(clave, dato) {
+			return false
+		}
+		if *modificaciones != inicial {
+			panic(mensajePanic("modificado"))
+		}
+		return true
+	}
+}
+
 func (h *hashCerrado[K, V]) Guardar(clave K, valor V) {
 	if h.verificarRedimension() {
 		h.rehash()
@@ -126,6 +160,7 @@ func (h *hashCerrado[K, V]) Guardar(clave K, valor V) {
 			h.borrados--
 		}
 		h.cantidad++
+		h.modificaciones++
 		elem.clave = clave
 		elem.valor = valor
 		elem.estado = OCUPADO
@@ -158,6 +193,7 @@ func (h *hashCerrado[K, V]) Borrar(clave K) V {
 			elem.estado = BORRADO
 			h.cantidad--
 			h.borrados++
+			h.modificaciones++
 			return valor
 		}
 		indice, elem = h.sondeoLineal(indice)
@@ -182,6 +218,7 @@ func (h *hashCerrado[K, V]) Iterar(visitar func(clave K, dato V) bool) {
 
 func (h *hashCerrado[K, V]) rehash() {
 	viejaTabla := h.tabla
+	h.modificaciones++
 	h.cantidad, h.borrados = 0, 0
 	h.inicializarTabla(len(viejaTabla) * FACTOR_EXPANSION)
 
@@ -194,10 +231,19 @@ func (h *hashCerrado[K, V]) rehash() {
 }
 
 func (h *hashCerrado[K, V]) Iterador() IterDiccionario[K, V] {
-	return &iteradorHash[K, V]{hash: h}
+	return &iteradorHash[K, V]{hash: h, modificaciones: h.modificaciones}
+}
+
+// PRE: el iterador debe de haber sido creado a partir de un hash existente
+// POST: entra en panico si el hash fue modificado por fuera del iterador desde que este fue creado
+func (it *iteradorHash[K, V]) verificarModificaciones() {
+	if it.modificaciones != it.hash.modificaciones {
+		panic(mensajePanic("modificado"))
+	}
 }
 
 func (it *iteradorHash[K, V]) HaySiguiente() bool {
+	it.verificarModificaciones()
 	for it.posicion < len(it.hash.tabla) {
 		if it.hash.tabla[it.posicion].estado == OCUPADO {
 			return true
@@ -221,3 +267,24 @@ func (it *iteradorHash[K, V]) Siguiente() {
 	}
 	it.posicion++
 }
+
+// Borrar no redimensiona la tabla, asi la posicion del iterador sigue siendo valida
+func (it *iteradorHash[K, V]) Borrar() (K, V) {
+	if !it.HaySiguiente() {
+		panic(mensajePanic("iterador"))
+	}
+	elem := &it.hash.tabla[it.posicion]
+	elem.estado = BORRADO
+	it.hash.cantidad--
+	it.hash.borrados++
+	it.hash.modificaciones++
+	it.modificaciones = it.hash.modificaciones
+	it.posicion++
+	return elem.clave, elem.valor
+}
+
+func (h *hashCerrado[K, V]) Todos() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		h.Iterar(fallarSiSeModifica(&h.modificaciones, yield))
+	}
+}
@@ -0,0 +1,331 @@
+package diccionario
+
+import (
+	"iter"
+	"strings"
+	TDAPila "tdas/pila"
+)
+
+const SEPARADOR_RUTA = "/"
+
+// Cada nodo representa un segmento de la ruta, y sus hijos se guardan en un ABB para recorrerlos en orden.
+// `claves` cuenta las claves del subarbol, lo que permite podar nodos vacios y saltearlos al iterar.
+type nodoRuta[V any] struct {
+	hijos     DiccionarioOrdenado[string, *nodoRuta[V]]
+	dato      V
+	tieneDato bool
+	claves    int
+}
+
+type trieRutas[V any] struct {
+	raiz           *nodoRuta[V]
+	modificaciones int
+}
+
+// Ruta visible al iterar los nodos acumulados, con el total de las claves bajo ella
+type rutaAcumulada[V any] struct {
+	ruta  string
+	nivel int
+	total V
+}
+
+// Cada marco guarda el iterador de los hijos de un ancestro del nodo actual, junto con la ruta de ese ancestro
+type marcoRutas[V any] struct {
+	padre  *nodoRuta[V]
+	hijos  IterDiccionario[string, *nodoRuta[V]]
+	ruta   string
+	esRaiz bool
+}
+
+type iteradorRutas[V any] struct {
+	trie           *trieRutas[V]
+	pila           TDAPila.Pila[*marcoRutas[V]]
+	actual         *nodoRuta[V]
+	clave          string
+	modificaciones int
+}
+
+// PRE:
+// POST: crea un nodo sin dato ni hijos
+func crearNodoRuta[V any]() *nodoRuta[V] {
+	return &nodoRuta[V]{hijos: CrearABB[string, *nodoRuta[V]](strings.Compare)}
+}
+
+func CrearDiccionarioRutas[V any]() DiccionarioRutas[V] {
+	return &trieRutas[V]{raiz: crearNodoRuta[V]()}
+}
+
+// PRE: esRaiz indica si ruta corresponde a la raiz del trie
+// POST: retorna la ruta del hijo con el segmento dado
+func unirRuta(ruta, segmento string, esRaiz bool) string {
+	if esRaiz {
+		return segmento
+	}
+	return ruta + SEPARADOR_RUTA + segmento
+}
+
+// PRE: la clave debe de ser una cadena valida
+// POST: retorna el nodo correspondiente a la clave, o nil si no existe
+func (t *trieRutas[V]) buscarNodo(clave string) *nodoRuta[V] {
+	nodo := t.raiz
+	for _, segmento := range strings.Split(clave, SEPARADOR_RUTA) {
+		if !nodo.hijos.Pertenece(segmento) {
+			return nil
+		}
+		nodo = nodo.hijos.Obtener(segmento)
+	}
+	return nodo
+}
+
+func (t *trieRutas[V]) Guardar(clave string, dato V) {
+	camino := []*nodoRuta[V]{t.raiz}
+	nodo := t.raiz
+	for _, segmento := range strings.Split(clave, SEPARADOR_RUTA) {
+		if !nodo.hijos.Pertenece(segmento) {
+			nodo.hijos.Guardar(segmento, crearNodoRuta[V]())
+		}
+		nodo = nodo.hijos.Obtener(segmento)
+		camino = append(camino, nodo)
+	}
+	if !nodo.tieneDato {
+		for _, ancestro := range camino {
+			ancestro.claves++
+		}
+		nodo.tieneDato = true
+		t.modificaciones++
+	}
+	nodo.dato = dato
+}
+
+func (t *trieRutas[V]) Pertenece(clave string) bool {
+	nodo := t.buscarNodo(clave)
+	return nodo != nil && nodo.tieneDato
+}
+
+func (t *trieRutas[V]) Obtener(clave string) V {
+	nodo := t.buscarNodo(clave)
+	if nodo == nil || !nodo.tieneDato {
+		panic(mensajePanic("diccionario"))
+	}
+	return nodo.dato
+}
+
+// Al borrar se podan los nodos que quedan sin claves en su subarbol
+func (t *trieRutas[V]) Borrar(clave string) V {
+	segmentos := strings.Split(clave, SEPARADOR_RUTA)
+	camino := []*nodoRuta[V]{t.raiz}
+	for _, segmento := range segmentos {
+		nodo := camino[len(camino)-1]
+		if !nodo.hijos.Pertenece(segmento) {
+			panic(mensajePanic("diccionario"))
+		}
+		camino = append(camino, nodo.hijos.Obtener(segmento))
+	}
+	nodo := camino[len(camino)-1]
+	if !nodo.tieneDato {
+		panic(mensajePanic("diccionario"))
+	}
+
+	var vacio V
+	dato := nodo.dato
+	nodo.dato, nodo.tieneDato = vacio, false
+	for _, ancestro := range camino {
+		ancestro.claves--
+	}
+	for i := len(segmentos) - 1; i >= 0 && camino[i+1].claves == 0; i-- {
+		camino[i].hijos.Borrar(segmentos[i])
+	}
+	t.modificaciones++
+	return dato
+}
+
+func (t *trieRutas[V]) Cantidad() int {
+	return t.raiz.claves
+}
+
+func (t *trieRutas[V]) CantidadPrefijo(prefijo string) int {
+	nodo := t.buscarNodo(prefijo)
+	if nodo == nil {
+		return 0
+	}
+	return nodo.claves
+}
+
+// PRE: nodo no debe de ser nil y ruta debe de ser su ruta
+// POST: aplica visitar en preorden a las claves del subarbol, devolviendo false si se corto la iteracion
+func (t *trieRutas[V]) iterarNodo(nodo *nodoRuta[V], ruta string, esRaiz bool, visitar func(clave string, dato V) bool) bool {
+	if nodo.tieneDato && !visitar(ruta, nodo.dato) {
+		return false
+	}
+	for segmento, hijo := range nodo.hijos.Todos() {
+		if hijo.claves > 0 && !t.iterarNodo(hijo, unirRuta(ruta, segmento, esRaiz), false, visitar) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *trieRutas[V]) Iterar(visitar func(clave string, dato V) bool) {
+	t.iterarNodo(t.raiz, "", true, visitar)
+}
+
+func (t *trieRutas[V]) IterarPrefijo(prefijo string, visitar func(clave string, dato V) bool) {
+	nodo := t.buscarNodo(prefijo)
+	if nodo != nil {
+		t.iterarNodo(nodo, prefijo, false, visitar)
+	}
+}
+
+func (t *trieRutas[V]) Todos() iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		t.Iterar(fallarSiSeModifica(&t.modificaciones, yield))
+	}
+}
+
+func (t *trieRutas[V]) TodosPrefijo(prefijo string) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		t.IterarPrefijo(prefijo, fallarSiSeModifica(&t.modificaciones, yield))
+	}
+}
+
+// PRE: nodo no debe de ser nil y ruta debe de ser su ruta
+// POST: aplica visitar en preorden a los hijos con claves hasta la profundidad maxima, devolviendo false si se
+// corto la iteracion
+func (t *trieRutas[V]) iterarNodos(nodo *nodoRuta[V], ruta string, esRaiz bool, nivel, profundidad int, visitar func(ruta string, profundidad int) bool) bool {
+	for segmento, hijo := range nodo.hijos.Todos() {
+		if hijo.claves == 0 {
+			continue
+		}
+		rutaHijo := unirRuta(ruta, segmento, esRaiz)
+		if !visitar(rutaHijo, nivel) {
+			return false
+		}
+		if nivel < profundidad && !t.iterarNodos(hijo, rutaHijo, false, nivel+1, profundidad, visitar) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *trieRutas[V]) IterarNodos(profundidad int, visitar func(ruta string, profundidad int) bool) {
+	t.iterarNodos(t.raiz, "", true, 0, profundidad, visitar)
+}
+
+// PRE: nodo no debe de ser nil y ruta debe de ser su ruta
+// POST: retorna el total de los datos del subarbol, agregando en preorden a visibles las rutas hasta la profundidad
+// maxima con su total, que se completa al terminar de recorrer su subarbol
+func (t *trieRutas[V]) acumularNodos(nodo *nodoRuta[V], ruta string, esRaiz bool, nivel, profundidad int, sumar func(acumulado, dato V) V, visibles *[]rutaAcumulada[V]) V {
+	var total V
+	if nodo.tieneDato {
+		total = sumar(total, nodo.dato)
+	}
+	for segmento, hijo := range nodo.hijos.Todos() {
+		if hijo.claves == 0 {
+			continue
+		}
+		rutaHijo := unirRuta(ruta, segmento, esRaiz)
+		posicion := len(*visibles)
+		if nivel <= profundidad {
+			*visibles = append(*visibles, rutaAcumulada[V]{ruta: rutaHijo, nivel: nivel})
+		}
+		totalHijo := t.acumularNodos(hijo, rutaHijo, false, nivel+1, profundidad, sumar, visibles)
+		if nivel <= profundidad {
+			(*visibles)[posicion].total = totalHijo
+		}
+		total = sumar(total, totalHijo)
+	}
+	return total
+}
+
+func (t *trieRutas[V]) IterarNodosAcumulados(profundidad int, sumar func(acumulado, dato V) V, visitar func(ruta string, profundidad int, total V) bool) {
+	var visibles []rutaAcumulada[V]
+	t.acumularNodos(t.raiz, "", true, 0, profundidad, sumar, &visibles)
+	for _, visible := range visibles {
+		if !visitar(visible.ruta, visible.nivel, visible.total) {
+			return
+		}
+	}
+}
+
+func (t *trieRutas[V]) Iterador() IterDiccionario[string, V] {
+	iter := &iteradorRutas[V]{
+		trie:           t,
+		pila:           TDAPila.CrearPilaDinamica[*marcoRutas[V]](),
+		modificaciones: t.modificaciones,
+	}
+	iter.pila.Apilar(&marcoRutas[V]{padre: t.raiz, hijos: t.raiz.hijos.Iterador(), esRaiz: true})
+	iter.avanzar()
+	return iter
+}
+
+// PRE: el iterador debe de haber sido creado a partir de un trie existente
+// POST: entra en panico si el trie fue modificado por fuera del iterador desde que este fue creado
+func (iter *iteradorRutas[V]) verificarModificaciones() {
+	if iter.modificaciones != iter.trie.modificaciones {
+		panic(mensajePanic("modificado"))
+	}
+}
+
+// PRE:
+// POST: posiciona al iterador en el siguiente nodo en preorden que tenga dato, o en nil si no quedan
+func (iter *iteradorRutas[V]) avanzar() {
+	if iter.actual != nil {
+		iter.pila.Apilar(&marcoRutas[V]{padre: iter.actual, hijos: iter.actual.hijos.Iterador(), ruta: iter.clave})
+	}
+	for !iter.pila.EstaVacia() {
+		marco := iter.pila.VerTope()
+		if !marco.hijos.HaySiguiente() {
+			iter.pila.Desapilar()
+			continue
+		}
+		segmento, hijo := marco.hijos.VerActual()
+		marco.hijos.Siguiente()
+		if hijo.claves == 0 {
+			continue
+		}
+		iter.actual, iter.clave = hijo, unirRuta(marco.ruta, segmento, marco.esRaiz)
+		if hijo.tieneDato {
+			return
+		}
+		iter.pila.Apilar(&marcoRutas[V]{padre: hijo, hijos: hijo.hijos.Iterador(), ruta: iter.clave})
+	}
+	iter.actual = nil
+}
+
+func (iter *iteradorRutas[V]) HaySiguiente() bool {
+	iter.verificarModificaciones()
+	return iter.actual != nil
+}
+
+func (iter *iteradorRutas[V]) VerActual() (string, V) {
+	if !iter.HaySiguiente() {
+		panic(mensajePanic("iterador"))
+	}
+	return iter.clave, iter.actual.dato
+}
+
+func (iter *iteradorRutas[V]) Siguiente() {
+	if !iter.HaySiguiente() {
+		panic(mensajePanic("iterador"))
+	}
+	iter.avanzar()
+}
+
+// Borrar no poda nodos, para no invalidar los iteradores de hijos apilados. Los nodos que quedan sin claves
+// se saltean al iterar y se podan en un Borrar posterior del diccionario.
+func (iter *iteradorRutas[V]) Borrar() (string, V) {
+	if !iter.HaySiguiente() {
+		panic(mensajePanic("iterador"))
+	}
+	var vacio V
+	clave, dato := iter.clave, iter.actual.dato
+	iter.actual.dato, iter.actual.tieneDato = vacio, false
+	iter.actual.claves--
+	for marco := range iter.pila.Todos() {
+		marco.padre.claves--
+	}
+	iter.trie.modificaciones++
+	iter.modificaciones = iter.trie.modificaciones
+	iter.avanzar()
+	return clave, dato
+}
@@ -1,6 +1,6 @@
 module tdas
 
-go 1.22.0
+go 1.23.0
 
 require github.com/stretchr/testify v1.9.0
 
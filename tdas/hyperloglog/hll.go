@@ -0,0 +1,130 @@
+package hyperloglog
+
+// Implementacion basada en el paper original de Flajolet, Fusy, Gandouet y Meunier (2007):
+// https://algo.inria.fr/flajolet/Publications/FlFuGaMe07.pdf
+//
+// Cada elemento se hashea a 64 bits. Los primeros `precision` bits eligen un registro, y en el se guarda el
+// maximo de (ceros a la izquierda + 1) del resto de los bits. Para cardinalidades chicas se usa linear counting.
+
+import (
+	"errors"
+	"hash/fnv"
+	"math"
+	"math/bits"
+)
+
+const (
+	PRECISION_MINIMA  = 4
+	PRECISION_MAXIMA  = 16
+	UMBRAL_LINEAL     = 2.5
+	MENSAJE_PRECISION = "Las precisiones no coinciden"
+)
+
+var errorSerializacion = errors.New("datos de HyperLogLog invalidos")
+
+type hyperLogLog struct {
+	registros []uint8
+	precision int
+}
+
+// PRE: la precision debe de estar entre PRECISION_MINIMA y PRECISION_MAXIMA
+// POST: crea un HyperLogLog vacio con 2^precision registros
+func CrearHyperLogLog(precision int) HyperLogLog {
+	if precision < PRECISION_MINIMA || precision > PRECISION_MAXIMA {
+		panic("La precision debe estar entre 4 y 16")
+	}
+	return &hyperLogLog{registros: make([]uint8, 1<<precision), precision: precision}
+}
+
+// PRE: datos debe de haber sido generado por Serializar
+// POST: devuelve el HyperLogLog serializado en datos, o un error si los datos no son validos
+func CargarHyperLogLog(datos []byte) (HyperLogLog, error) {
+	if len(datos) == 0 {
+		return nil, errorSerializacion
+	}
+	precision := int(datos[0])
+	if precision < PRECISION_MINIMA || precision > PRECISION_MAXIMA || len(datos) != 1+(1<<precision) {
+		return nil, errorSerializacion
+	}
+	h := &hyperLogLog{registros: make([]uint8, 1<<precision), precision: precision}
+	copy(h.registros, datos[1:])
+	return h, nil
+}
+
+// PRE:
+// POST: hashea el elemento con FNV-1a y mezcla el resultado (finalizador de MurmurHash3), ya que HyperLogLog
+// necesita que todos los bits del hash esten bien distribuidos
+func hashear(elemento string) uint64 {
+	fnv1a := fnv.New64a()
+	fnv1a.Write([]byte(elemento))
+	hash := fnv1a.Sum64()
+	hash ^= hash >> 33
+	hash *= 0xff51afd7ed558ccd
+	hash ^= hash >> 33
+	hash *= 0xc4ceb9fe1a85ec53
+	hash ^= hash >> 33
+	return hash
+}
+
+// PRE: la cantidad de registros m debe de ser potencia de 2 mayor o igual a 16
+// POST: retorna la constante de correccion alfa_m del paper
+func alfa(m int) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	}
+	return 0.7213 / (1 + 1.079/float64(m))
+}
+
+func (h *hyperLogLog) Agregar(elemento string) {
+	hash := hashear(elemento)
+	indice := hash >> (64 - h.precision)
+	// El bit agregado acota el rango, para que un resto de todos ceros no cuente mas alla de los bits disponibles
+	rango := uint8(bits.LeadingZeros64(hash<<h.precision|1<<(h.precision-1)) + 1)
+	if rango > h.registros[indice] {
+		h.registros[indice] = rango
+	}
+}
+
+func (h *hyperLogLog) Estimar() uint64 {
+	m := float64(len(h.registros))
+	suma := 0.0
+	vacios := 0
+	for _, registro := range h.registros {
+		suma += math.Ldexp(1, -int(registro))
+		if registro == 0 {
+			vacios++
+		}
+	}
+	estimacion := alfa(len(h.registros)) * m * m / suma
+	if estimacion <= UMBRAL_LINEAL*m && vacios > 0 {
+		estimacion = m * math.Log(m/float64(vacios))
+	}
+	return uint64(math.Round(estimacion))
+}
+
+func (h *hyperLogLog) Unir(otro HyperLogLog) {
+	if otro.Precision() != h.precision {
+		panic(MENSAJE_PRECISION)
+	}
+	for i, registro := range otro.(*hyperLogLog).registros {
+		if registro > h.registros[i] {
+			h.registros[i] = registro
+		}
+	}
+}
+
+func (h *hyperLogLog) Precision() int {
+	return h.precision
+}
+
+func (h *hyperLogLog) Serializar() []byte {
+	datos := make([]byte, 1+len(h.registros))
+	datos[0] = byte(h.precision)
+	copy(datos[1:], h.registros)
+	return datos
+}
@@ -0,0 +1,23 @@
+package hyperloglog
+
+// HyperLogLog estima la cantidad de elementos distintos agregados usando memoria constante: 2^precision
+// registros de un byte. El error estandar de la estimacion es de 1.04/sqrt(2^precision), por ejemplo
+// 0.81% con precision 14 (16 KiB) o 3.25% con precision 10 (1 KiB).
+type HyperLogLog interface {
+
+	// Agregar registra un elemento. Agregar varias veces el mismo elemento no modifica la estimacion.
+	Agregar(elemento string)
+
+	// Estimar devuelve la cantidad aproximada de elementos distintos agregados.
+	Estimar() uint64
+
+	// Unir incorpora los elementos de otro HyperLogLog, como si se hubieran agregado a este. Si las precisiones
+	// no coinciden, entra en pánico con un mensaje "Las precisiones no coinciden".
+	Unir(otro HyperLogLog)
+
+	// Precision devuelve la precision con la que fue creado.
+	Precision() int
+
+	// Serializar devuelve una representacion en bytes que se puede volver a cargar con CargarHyperLogLog.
+	Serializar() []byte
+}
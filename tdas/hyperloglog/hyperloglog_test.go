@@ -0,0 +1,99 @@
+package hyperloglog_test
+
+import (
+	"fmt"
+	"math"
+	TDAHLL "tdas/hyperloglog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// PRE: real debe de ser mayor a 0
+// POST: retorna el error relativo de la estimacion
+func errorRelativo(estimacion uint64, real int) float64 {
+	return math.Abs(float64(estimacion)-float64(real)) / float64(real)
+}
+
+func TestHyperLogLogVacio(t *testing.T) {
+	hll := TDAHLL.CrearHyperLogLog(14)
+	require.Equal(t, uint64(0), hll.Estimar(), "Un HyperLogLog vacio deberia estimar 0 elementos")
+	require.Equal(t, 14, hll.Precision())
+	require.Panics(t, func() { TDAHLL.CrearHyperLogLog(3) }, "La precision minima es 4")
+	require.Panics(t, func() { TDAHLL.CrearHyperLogLog(17) }, "La precision maxima es 16")
+}
+
+func TestHyperLogLogRepetidos(t *testing.T) {
+	hll := TDAHLL.CrearHyperLogLog(14)
+	for i := 0; i < 1000; i++ {
+		hll.Agregar("83.149.9.216")
+		hll.Agregar("66.249.73.185")
+	}
+	require.Equal(t, uint64(2), hll.Estimar(), "Agregar elementos repetidos no deberia aumentar la estimacion")
+}
+
+func TestHyperLogLogCardinalidadesChicas(t *testing.T) {
+	// Con pocos elementos, linear counting da estimaciones exactas o casi exactas
+	hll := TDAHLL.CrearHyperLogLog(14)
+	for i := 1; i <= 100; i++ {
+		hll.Agregar(fmt.Sprintf("10.0.0.%d", i))
+	}
+	require.InDelta(t, 100, hll.Estimar(), 1, "Se espera una estimacion casi exacta para 100 elementos")
+}
+
+func TestHyperLogLogVolumen(t *testing.T) {
+	for _, precision := range []int{10, 14} {
+		cotaError := 3 * 1.04 / math.Sqrt(float64(int(1)<<precision))
+		for _, cantidad := range []int{1000, 50000, 500000} {
+			hll := TDAHLL.CrearHyperLogLog(precision)
+			for i := 0; i < cantidad; i++ {
+				hll.Agregar(fmt.Sprintf("%d.%d.%d.%d", i>>24&0xFF, i>>16&0xFF, i>>8&0xFF, i&0xFF))
+			}
+			require.LessOrEqual(t, errorRelativo(hll.Estimar(), cantidad), cotaError,
+				"La estimacion de %d elementos con precision %d excede el error esperado", cantidad, precision)
+		}
+	}
+}
+
+func TestHyperLogLogUnir(t *testing.T) {
+	ayer := TDAHLL.CrearHyperLogLog(12)
+	hoy := TDAHLL.CrearHyperLogLog(12)
+	for i := 0; i < 30000; i++ {
+		ayer.Agregar(fmt.Sprintf("ip%d", i))
+	}
+	for i := 20000; i < 50000; i++ {
+		hoy.Agregar(fmt.Sprintf("ip%d", i))
+	}
+
+	// La union estima los elementos distintos de ambos, sin contar dos veces a los compartidos
+	ayer.Unir(hoy)
+	require.LessOrEqual(t, errorRelativo(ayer.Estimar(), 50000), 0.05)
+
+	otraPrecision := TDAHLL.CrearHyperLogLog(10)
+	require.PanicsWithValue(t, "Las precisiones no coinciden", func() { ayer.Unir(otraPrecision) })
+}
+
+func TestHyperLogLogSerializar(t *testing.T) {
+	hll := TDAHLL.CrearHyperLogLog(10)
+	for i := 0; i < 5000; i++ {
+		hll.Agregar(fmt.Sprintf("ip%d", i))
+	}
+	datos := hll.Serializar()
+	require.Len(t, datos, 1+1024)
+
+	cargado, err := TDAHLL.CargarHyperLogLog(datos)
+	require.NoError(t, err)
+	require.Equal(t, hll.Precision(), cargado.Precision())
+	require.Equal(t, hll.Estimar(), cargado.Estimar(), "El HyperLogLog cargado deberia estimar lo mismo")
+
+	// El cargado es independiente del original
+	cargado.Agregar("ip-nueva")
+	require.Equal(t, datos, hll.Serializar())
+
+	_, err = TDAHLL.CargarHyperLogLog(nil)
+	require.Error(t, err, "No se puede cargar un HyperLogLog sin datos")
+	_, err = TDAHLL.CargarHyperLogLog(datos[:100])
+	require.Error(t, err, "No se puede cargar un HyperLogLog truncado")
+	_, err = TDAHLL.CargarHyperLogLog([]byte{30, 0, 0})
+	require.Error(t, err, "No se puede cargar un HyperLogLog con precision invalida")
+}
@@ -1,5 +1,7 @@
 package lista
 
+import "iter"
+
 type Lista[T any] interface {
 
 	// EstaVacia devuelve verdadero si la lista no tiene elementos, false en caso contrario.
@@ -32,6 +34,10 @@ type Lista[T any] interface {
 	// Iterador recibe un tipo de dato correspondiente a la lista.
 	// Devuelve un iterador de lista con elementos del tipo de dato recibido.
 	Iterador() IteradorLista[T]
+
+	// Todos devuelve un iter.Seq que recorre los elementos de la lista del primero al ultimo, para usar con
+	// 'for elem := range lista.Todos()'.
+	Todos() iter.Seq[T]
 }
 
 type IteradorLista[T any] interface {
@@ -0,0 +1,65 @@
+package lista
+
+import "iter"
+
+type ListaDoble[T any] interface {
+	Lista[T]
+
+	// BorrarUltimo saca el ultimo elemento de la lista y lo devuelve.
+	// Si la lista está vacía, entra en pánico con un mensaje "La lista esta vacia".
+	BorrarUltimo() T
+
+	// IteradorDoble devuelve un iterador bidireccional posicionado en el primer elemento de la lista.
+	IteradorDoble() IteradorListaDoble[T]
+
+	// IteradorAlFinal devuelve un iterador bidireccional posicionado en el ultimo elemento de la lista.
+	IteradorAlFinal() IteradorListaDoble[T]
+
+	// IterarAlReves recibe una funcion "visitar" y la aplica a los elementos desde el ultimo hasta el primero,
+	// mientras devuelva true.
+	IterarAlReves(visitar func(T) bool)
+
+	// TodosAlReves devuelve un iter.Seq que recorre los elementos de la lista del ultimo al primero.
+	TodosAlReves() iter.Seq[T]
+
+	// InsertarPrimeroNodo agrega un nuevo elemento al principio de la lista y devuelve su nodo, que permite
+	// borrarlo o moverlo en O(1) mientras siga en la lista (por ejemplo, para implementar un cache LRU).
+	InsertarPrimeroNodo(elemento T) *NodoListaDoble[T]
+
+	// InsertarUltimoNodo agrega un nuevo elemento al final de la lista y devuelve su nodo.
+	InsertarUltimoNodo(elemento T) *NodoListaDoble[T]
+
+	// Borrar saca de la lista el elemento del nodo en O(1) y lo devuelve. Si el nodo no esta en esta lista (porque
+	// ya se borro o porque es de otra), entra en pánico con un mensaje "El nodo no pertenece a la lista".
+	Borrar(nodo *NodoListaDoble[T]) T
+
+	// MoverAlFrente mueve el elemento del nodo al principio de la lista en O(1); el nodo sigue siendo valido.
+	// Si el nodo no esta en esta lista, entra en pánico con un mensaje "El nodo no pertenece a la lista".
+	MoverAlFrente(nodo *NodoListaDoble[T])
+
+	// Concatenar mueve todos los elementos de otra lista al final de esta en O(1), dejando a la otra vacia. Los
+	// nodos de los elementos movidos pasan a pertenecer a esta lista. Si otra es la misma lista, entra en pánico con
+	// un mensaje "No se puede concatenar una lista consigo misma", y si no fue creada con
+	// CrearListaDoblementeEnlazada, con un mensaje "La otra lista no es una lista doblemente enlazada".
+	Concatenar(otra ListaDoble[T])
+
+	// Empalmar mueve todos los elementos de otra lista a esta en O(1), insertandolos antes del elemento actual
+	// del iterador (o al final, si el iterador termino de iterar). La otra lista queda vacia y el iterador queda
+	// posicionado en el primero de los elementos empalmados. Si el iterador no pertenece a esta lista, entra en
+	// pánico con un mensaje "El iterador no pertenece a la lista"; si otra es la misma lista, con un mensaje
+	// "No se puede empalmar una lista consigo misma", y si no fue creada con CrearListaDoblementeEnlazada, con un
+	// mensaje "La otra lista no es una lista doblemente enlazada".
+	Empalmar(iterador IteradorListaDoble[T], otra ListaDoble[T])
+}
+
+type IteradorListaDoble[T any] interface {
+	IteradorLista[T]
+
+	// HayAnterior devuelve verdadero si hay un elemento antes de la posicion actual del iterador. Si el iterador
+	// termino de iterar, el anterior es el ultimo elemento de la lista.
+	HayAnterior() bool
+
+	// Anterior retrocede el iterador al elemento anterior de la lista. Si no HayAnterior, entra en pánico con un
+	// mensaje "El iterador esta al principio".
+	Anterior()
+}
@@ -0,0 +1,273 @@
+package lista_test
+
+import (
+	TDALista "tdas/lista"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// PRE:
+// POST: crea una lista doble con los elementos dados, en orden
+func crearListaDoble(elementos ...int) TDALista.ListaDoble[int] {
+	lista := TDALista.CrearListaDoblementeEnlazada[int]()
+	for _, elem := range elementos {
+		lista.InsertarUltimo(elem)
+	}
+	return lista
+}
+
+// PRE:
+// POST: devuelve los elementos de la lista recorridos hacia adelante
+func elementosDe(lista TDALista.ListaDoble[int]) []int {
+	elementos := []int{}
+	for elem := range lista.Todos() {
+		elementos = append(elementos, elem)
+	}
+	return elementos
+}
+
+// PRE:
+// POST: devuelve los elementos de la lista recorridos hacia atras
+func elementosAlReves(lista TDALista.ListaDoble[int]) []int {
+	elementos := []int{}
+	for elem := range lista.TodosAlReves() {
+		elementos = append(elementos, elem)
+	}
+	return elementos
+}
+
+func TestListaDobleVacia(t *testing.T) {
+	lista := TDALista.CrearListaDoblementeEnlazada[int]()
+
+	require.True(t, lista.EstaVacia(), "La lista debería estar vacia al ser creada.")
+	require.Equal(t, 0, lista.Largo(), "La lista deberia tener 0 elementos al ser creada")
+	require.PanicsWithValue(t, "La lista esta vacia", func() { lista.VerPrimero() })
+	require.PanicsWithValue(t, "La lista esta vacia", func() { lista.VerUltimo() })
+	require.PanicsWithValue(t, "La lista esta vacia", func() { lista.BorrarPrimero() })
+	require.PanicsWithValue(t, "La lista esta vacia", func() { lista.BorrarUltimo() })
+
+	iter := lista.IteradorDoble()
+	require.False(t, iter.HaySiguiente(), "El iterador no deberia de tener siguiente en una lista vacia")
+	require.False(t, iter.HayAnterior(), "El iterador no deberia de tener anterior en una lista vacia")
+	require.PanicsWithValue(t, "El iterador esta al principio", func() { iter.Anterior() })
+	require.False(t, lista.IteradorAlFinal().HaySiguiente(), "El iterador al final de una lista vacia termino de iterar")
+}
+
+func TestListaDobleBorrarPorAmbosExtremos(t *testing.T) {
+	lista := crearListaDoble(1, 2, 3, 4)
+	lista.InsertarPrimero(0)
+
+	require.Equal(t, 4, lista.BorrarUltimo(), "El ultimo borrado deberia ser 4")
+	require.Equal(t, 3, lista.VerUltimo(), "El nuevo ultimo deberia ser 3")
+	require.Equal(t, 0, lista.BorrarPrimero(), "El primero borrado deberia ser 0")
+	require.Equal(t, []int{1, 2, 3}, elementosDe(lista))
+	require.Equal(t, []int{3, 2, 1}, elementosAlReves(lista))
+
+	lista.BorrarUltimo()
+	lista.BorrarUltimo()
+	require.Equal(t, 1, lista.BorrarUltimo())
+	require.True(t, lista.EstaVacia(), "La lista deberia quedar vacia")
+
+	// Una vez vacia se comporta como recien creada
+	lista.InsertarUltimo(7)
+	require.Equal(t, 7, lista.VerPrimero())
+	require.Equal(t, 7, lista.VerUltimo())
+}
+
+func TestListaDobleIteradorBidireccional(t *testing.T) {
+	lista := crearListaDoble(1, 2, 3)
+	iter := lista.IteradorDoble()
+
+	// Avanzamos hasta terminar de iterar y volvemos hasta el principio
+	iter.Siguiente()
+	iter.Siguiente()
+	iter.Siguiente()
+	require.False(t, iter.HaySiguiente())
+	require.True(t, iter.HayAnterior(), "Al terminar de iterar, el anterior es el ultimo elemento")
+	iter.Anterior()
+	require.Equal(t, 3, iter.VerActual())
+	iter.Anterior()
+	require.Equal(t, 2, iter.VerActual())
+	iter.Anterior()
+	require.Equal(t, 1, iter.VerActual())
+	require.False(t, iter.HayAnterior())
+	require.PanicsWithValue(t, "El iterador esta al principio", func() { iter.Anterior() })
+
+	// El iterador al final recorre hacia atras
+	recorridos := []int{}
+	for iter := lista.IteradorAlFinal(); iter.HaySiguiente(); {
+		recorridos = append(recorridos, iter.VerActual())
+		if !iter.HayAnterior() {
+			break
+		}
+		iter.Anterior()
+	}
+	require.Equal(t, []int{3, 2, 1}, recorridos)
+}
+
+func TestListaDobleIteradorInsertarYBorrar(t *testing.T) {
+	lista := crearListaDoble(1, 2, 3)
+	iter := lista.IteradorAlFinal()
+
+	// Borrar el ultimo deja al iterador al final, y el anterior pasa a ser el nuevo ultimo
+	require.Equal(t, 3, iter.Borrar())
+	require.False(t, iter.HaySiguiente())
+	require.Equal(t, 2, lista.VerUltimo())
+	iter.Anterior()
+	require.Equal(t, 2, iter.VerActual())
+
+	// Insertar agrega antes del actual y queda posicionado en el nuevo elemento
+	iter.Insertar(5)
+	require.Equal(t, 5, iter.VerActual())
+	require.Equal(t, []int{1, 5, 2}, elementosDe(lista))
+	require.Equal(t, []int{2, 5, 1}, elementosAlReves(lista))
+
+	iter.Anterior()
+	require.Equal(t, 1, iter.Borrar(), "Borrar el primero deberia devolver 1")
+	require.Equal(t, 5, lista.VerPrimero())
+	require.False(t, iter.HayAnterior())
+	require.Equal(t, 2, lista.Largo())
+}
+
+func TestListaDobleConcatenar(t *testing.T) {
+	lista := crearListaDoble(1, 2)
+	otra := crearListaDoble(3, 4, 5)
+
+	lista.Concatenar(otra)
+	require.Equal(t, []int{1, 2, 3, 4, 5}, elementosDe(lista))
+	require.Equal(t, []int{5, 4, 3, 2, 1}, elementosAlReves(lista))
+	require.Equal(t, 5, lista.Largo())
+	require.True(t, otra.EstaVacia(), "La lista concatenada deberia quedar vacia")
+
+	// Concatenar una lista vacia, o sobre una lista vacia
+	lista.Concatenar(otra)
+	require.Equal(t, 5, lista.Largo())
+	otra.Concatenar(lista)
+	require.Equal(t, []int{1, 2, 3, 4, 5}, elementosDe(otra))
+	require.Equal(t, 5, otra.VerUltimo())
+
+	require.PanicsWithValue(t, "No se puede concatenar una lista consigo misma", func() { otra.Concatenar(otra) })
+}
+
+func TestListaDobleEmpalmar(t *testing.T) {
+	lista := crearListaDoble(1, 4)
+	iter := lista.IteradorDoble()
+	iter.Siguiente()
+
+	lista.Empalmar(iter, crearListaDoble(2, 3))
+	require.Equal(t, 2, iter.VerActual(), "El iterador queda en el primer elemento empalmado")
+	require.Equal(t, []int{1, 2, 3, 4}, elementosDe(lista))
+	require.Equal(t, []int{4, 3, 2, 1}, elementosAlReves(lista))
+
+	// Empalmar con el iterador al final equivale a concatenar
+	finalizado := lista.IteradorAlFinal()
+	finalizado.Siguiente()
+	lista.Empalmar(finalizado, crearListaDoble(5))
+	require.Equal(t, 5, lista.VerUltimo())
+
+	// Empalmar al principio actualiza el primero
+	lista.Empalmar(lista.IteradorDoble(), crearListaDoble(0))
+	require.Equal(t, []int{0, 1, 2, 3, 4, 5}, elementosDe(lista))
+	require.Equal(t, 6, lista.Largo())
+
+	ajena := crearListaDoble(9)
+	require.PanicsWithValue(t, "El iterador no pertenece a la lista", func() {
+		lista.Empalmar(ajena.IteradorDoble(), crearListaDoble(7))
+	})
+}
+
+func TestListaDobleEmpalmarConsigoMisma(t *testing.T) {
+	lista := crearListaDoble(1, 2, 3)
+	iter := lista.IteradorDoble()
+	iter.Siguiente()
+	require.PanicsWithValue(t, "No se puede empalmar una lista consigo misma", func() { lista.Empalmar(iter, lista) })
+	require.Equal(t, []int{1, 2, 3}, elementosDe(lista), "La lista no se modifica")
+	require.Equal(t, 2, iter.VerActual())
+}
+
+func TestListaDobleEmpalmarOtraImplementacion(t *testing.T) {
+	// Envuelve una lista doble para que tenga otro tipo dinamico
+	type envoltorio struct{ TDALista.ListaDoble[int] }
+	lista := crearListaDoble(1, 2)
+	otra := envoltorio{crearListaDoble(3)}
+	require.PanicsWithValue(t, "La otra lista no es una lista doblemente enlazada", func() { lista.Concatenar(otra) })
+	require.PanicsWithValue(t, "La otra lista no es una lista doblemente enlazada", func() {
+		lista.Empalmar(lista.IteradorDoble(), otra)
+	})
+	require.Equal(t, []int{1, 2}, elementosDe(lista))
+	require.Equal(t, []int{3}, elementosDe(otra))
+}
+
+func TestListaDobleNodos(t *testing.T) {
+	lista := TDALista.CrearListaDoblementeEnlazada[int]()
+	dos := lista.InsertarUltimoNodo(2)
+	uno := lista.InsertarPrimeroNodo(1)
+	tres := lista.InsertarUltimoNodo(3)
+	require.Equal(t, 1, uno.Dato())
+	require.Equal(t, []int{1, 2, 3}, elementosDe(lista))
+
+	// Uso tipo LRU: el ultimo accedido pasa al frente y se desaloja por el final
+	lista.MoverAlFrente(tres)
+	require.Equal(t, []int{3, 1, 2}, elementosDe(lista))
+	require.Equal(t, []int{2, 1, 3}, elementosAlReves(lista))
+	lista.MoverAlFrente(tres)
+	require.Equal(t, []int{3, 1, 2}, elementosDe(lista), "Mover el primero al frente no cambia nada")
+	require.Equal(t, 1, lista.Borrar(uno))
+	require.Equal(t, []int{3, 2}, elementosDe(lista))
+	require.Equal(t, 2, lista.Borrar(dos))
+	require.Equal(t, 3, lista.VerUltimo())
+	require.Equal(t, 1, lista.Largo())
+}
+
+func TestListaDobleNodoAjeno(t *testing.T) {
+	lista := crearListaDoble(1, 2)
+	otra := TDALista.CrearListaDoblementeEnlazada[int]()
+	nodo := otra.InsertarUltimoNodo(3)
+	require.PanicsWithValue(t, "El nodo no pertenece a la lista", func() { lista.Borrar(nodo) })
+	require.PanicsWithValue(t, "El nodo no pertenece a la lista", func() { lista.MoverAlFrente(nodo) })
+	require.PanicsWithValue(t, "El nodo no pertenece a la lista", func() { lista.Borrar(nil) })
+
+	otra.Borrar(nodo)
+	require.PanicsWithValue(t, "El nodo no pertenece a la lista", func() { otra.Borrar(nodo) }, "Un nodo borrado ya no es valido")
+	require.Equal(t, []int{1, 2}, elementosDe(lista))
+	require.True(t, otra.EstaVacia())
+}
+
+func TestListaDobleNodosEmpalmados(t *testing.T) {
+	lista := crearListaDoble(1, 2)
+	otra := TDALista.CrearListaDoblementeEnlazada[int]()
+	tres := otra.InsertarUltimoNodo(3)
+	cuatro := otra.InsertarUltimoNodo(4)
+	lista.Concatenar(otra)
+
+	// Los nodos movidos pasan a pertenecer a la lista que los recibio
+	require.PanicsWithValue(t, "El nodo no pertenece a la lista", func() { otra.Borrar(tres) })
+	lista.MoverAlFrente(cuatro)
+	require.Equal(t, []int{4, 1, 2, 3}, elementosDe(lista))
+
+	// Se pueden empalmar varias veces, y la otra lista sigue siendo utilizable
+	cinco := otra.InsertarUltimoNodo(5)
+	tercera := TDALista.CrearListaDoblementeEnlazada[int]()
+	tercera.Concatenar(lista)
+	tercera.Concatenar(otra)
+	require.Equal(t, 3, tercera.Borrar(tres))
+	require.Equal(t, 5, tercera.Borrar(cinco))
+	require.PanicsWithValue(t, "El nodo no pertenece a la lista", func() { lista.Borrar(cuatro) })
+	require.Equal(t, []int{4, 1, 2}, elementosDe(tercera))
+	require.True(t, lista.EstaVacia())
+	require.True(t, otra.EstaVacia())
+}
+
+func TestListaDobleVolumen(t *testing.T) {
+	lista := TDALista.CrearListaDoblementeEnlazada[int]()
+	for i := 0; i < 10000; i++ {
+		lista.InsertarUltimo(i)
+	}
+	// Como una cola de eventos recientes: se descartan los mas viejos por un extremo y los mas nuevos por el otro
+	for i := 0; i < 5000; i++ {
+		require.Equal(t, i, lista.BorrarPrimero())
+		require.Equal(t, 9999-i, lista.BorrarUltimo())
+	}
+	require.True(t, lista.EstaVacia())
+}
@@ -0,0 +1,338 @@
+package lista
+
+import "iter"
+
+// NodoListaDoble es la posicion de un elemento dentro de una lista doble. Lo devuelven InsertarPrimeroNodo e
+// InsertarUltimoNodo, y sirve para borrar o mover ese elemento en O(1) sin recorrer la lista.
+type NodoListaDoble[T any] struct {
+	dato        T
+	anterior    *NodoListaDoble[T]
+	siguiente   *NodoListaDoble[T]
+	pertenencia *pertenencia[T]
+}
+
+// Indica a que lista pertenecen los nodos que la referencian. Al empalmar, la pertenencia de la otra lista pasa a
+// redirigir a la de esta, asi los nodos movidos cambian de lista sin tener que recorrerlos uno por uno.
+type pertenencia[T any] struct {
+	lista    *listaDoblementeEnlazada[T]
+	redirige *pertenencia[T]
+}
+
+type listaDoblementeEnlazada[T any] struct {
+	primero     *NodoListaDoble[T]
+	ultimo      *NodoListaDoble[T]
+	largo       int
+	pertenencia *pertenencia[T]
+}
+
+// A diferencia de la lista simple, no hace falta guardar el anterior: cada nodo conoce al suyo.
+// Si actual es nil, el iterador termino de iterar.
+type iteradorListaDoble[T any] struct {
+	actual *NodoListaDoble[T]
+	lista  *listaDoblementeEnlazada[T]
+}
+
+/*
+ *	Pre: Tipo de dato para elementos de la lista.
+ *	Post: Devuelve una lista doblemente enlazada.
+ */
+func CrearListaDoblementeEnlazada[T any]() ListaDoble[T] {
+	lista := &listaDoblementeEnlazada[T]{}
+	lista.pertenencia = &pertenencia[T]{lista: lista}
+	return lista
+}
+
+/*
+ *	Pre:
+ *	Post: devuelve el dato guardado en el nodo.
+ */
+func (nodo *NodoListaDoble[T]) Dato() T {
+	return nodo.dato
+}
+
+/*
+ *	Pre:
+ *	Post: devuelve true si el nodo esta enlazado en esta lista. Comprime el camino de redirecciones de su pertenencia.
+ */
+func (lista *listaDoblementeEnlazada[T]) contiene(nodo *NodoListaDoble[T]) bool {
+	if nodo == nil || nodo.pertenencia == nil {
+		return false
+	}
+	raiz := nodo.pertenencia
+	for raiz.redirige != nil {
+		raiz = raiz.redirige
+	}
+	for actual := nodo.pertenencia; actual != raiz; {
+		actual.redirige, actual = raiz, actual.redirige
+	}
+	nodo.pertenencia = raiz
+	return raiz.lista == lista
+}
+
+/*
+ *	Pre: otra es cualquier ListaDoble.
+ *	Post: devuelve otra como lista doblemente enlazada. Entra en panico si es otra implementacion.
+ */
+func comoListaDoblementeEnlazada[T any](otra ListaDoble[T]) *listaDoblementeEnlazada[T] {
+	origen, ok := otra.(*listaDoblementeEnlazada[T])
+	if !ok {
+		panic("La otra lista no es una lista doblemente enlazada")
+	}
+	return origen
+}
+
+/*
+ *	Pre: nuevoNodo no pertenece a ninguna lista y siguiente es un nodo de la lista o nil.
+ *	Post: enlaza nuevoNodo antes de siguiente, o al final de la lista si siguiente es nil.
+ */
+func (lista *listaDoblementeEnlazada[T]) enlazarAntesDe(nuevoNodo, siguiente *NodoListaDoble[T]) {
+	if siguiente == nil {
+		nuevoNodo.anterior = lista.ultimo
+		lista.ultimo = nuevoNodo
+	} else {
+		nuevoNodo.anterior = siguiente.anterior
+		siguiente.anterior = nuevoNodo
+	}
+	nuevoNodo.siguiente = siguiente
+	nuevoNodo.pertenencia = lista.pertenencia
+	if nuevoNodo.anterior == nil {
+		lista.primero = nuevoNodo
+	} else {
+		nuevoNodo.anterior.siguiente = nuevoNodo
+	}
+	lista.largo++
+}
+
+/*
+ *	Pre: nodo pertenece a la lista.
+ *	Post: desenlaza el nodo de la lista en O(1) y devuelve su dato.
+ */
+func (lista *listaDoblementeEnlazada[T]) desenlazar(nodo *NodoListaDoble[T]) T {
+	if nodo.anterior == nil {
+		lista.primero = nodo.siguiente
+	} else {
+		nodo.anterior.siguiente = nodo.siguiente
+	}
+	if nodo.siguiente == nil {
+		lista.ultimo = nodo.anterior
+	} else {
+		nodo.siguiente.anterior = nodo.anterior
+	}
+	lista.largo--
+	nodo.anterior, nodo.siguiente, nodo.pertenencia = nil, nil, nil
+	return nodo.dato
+}
+
+/*
+ *	Pre: origen es una lista doblemente enlazada distinta a esta y siguiente es un nodo de esta lista o nil.
+ *	Post: mueve todos los nodos de origen antes de siguiente, dejando a origen vacia y con una pertenencia nueva.
+ *	Devuelve el primer nodo movido.
+ */
+func (lista *listaDoblementeEnlazada[T]) empalmarAntesDe(origen *listaDoblementeEnlazada[T], siguiente *NodoListaDoble[T]) *NodoListaDoble[T] {
+	primero := origen.primero
+	if origen.EstaVacia() {
+		return siguiente
+	}
+
+	anterior := lista.ultimo
+	if siguiente != nil {
+		anterior = siguiente.anterior
+	}
+	primero.anterior = anterior
+	origen.ultimo.siguiente = siguiente
+	if anterior == nil {
+		lista.primero = primero
+	} else {
+		anterior.siguiente = primero
+	}
+	if siguiente == nil {
+		lista.ultimo = origen.ultimo
+	} else {
+		siguiente.anterior = origen.ultimo
+	}
+	lista.largo += origen.largo
+
+	origen.pertenencia.lista, origen.pertenencia.redirige = nil, lista.pertenencia
+	origen.pertenencia = &pertenencia[T]{lista: origen}
+	origen.primero, origen.ultimo, origen.largo = nil, nil, 0
+	return primero
+}
+
+func (lista *listaDoblementeEnlazada[T]) EstaVacia() bool {
+	return lista.largo == 0
+}
+
+func (lista *listaDoblementeEnlazada[T]) InsertarPrimero(elemento T) {
+	lista.enlazarAntesDe(&NodoListaDoble[T]{dato: elemento}, lista.primero)
+}
+
+func (lista *listaDoblementeEnlazada[T]) InsertarUltimo(elemento T) {
+	lista.enlazarAntesDe(&NodoListaDoble[T]{dato: elemento}, nil)
+}
+
+func (lista *listaDoblementeEnlazada[T]) InsertarPrimeroNodo(elemento T) *NodoListaDoble[T] {
+	nuevoNodo := &NodoListaDoble[T]{dato: elemento}
+	lista.enlazarAntesDe(nuevoNodo, lista.primero)
+	return nuevoNodo
+}
+
+func (lista *listaDoblementeEnlazada[T]) InsertarUltimoNodo(elemento T) *NodoListaDoble[T] {
+	nuevoNodo := &NodoListaDoble[T]{dato: elemento}
+	lista.enlazarAntesDe(nuevoNodo, nil)
+	return nuevoNodo
+}
+
+func (lista *listaDoblementeEnlazada[T]) Borrar(nodo *NodoListaDoble[T]) T {
+	if !lista.contiene(nodo) {
+		panic("El nodo no pertenece a la lista")
+	}
+	return lista.desenlazar(nodo)
+}
+
+func (lista *listaDoblementeEnlazada[T]) MoverAlFrente(nodo *NodoListaDoble[T]) {
+	if !lista.contiene(nodo) {
+		panic("El nodo no pertenece a la lista")
+	}
+	if nodo == lista.primero {
+		return
+	}
+	lista.desenlazar(nodo)
+	lista.enlazarAntesDe(nodo, lista.primero)
+}
+
+func (lista *listaDoblementeEnlazada[T]) BorrarPrimero() T {
+	if lista.EstaVacia() {
+		panic("La lista esta vacia")
+	}
+	return lista.desenlazar(lista.primero)
+}
+
+func (lista *listaDoblementeEnlazada[T]) BorrarUltimo() T {
+	if lista.EstaVacia() {
+		panic("La lista esta vacia")
+	}
+	return lista.desenlazar(lista.ultimo)
+}
+
+func (lista *listaDoblementeEnlazada[T]) VerPrimero() T {
+	if lista.EstaVacia() {
+		panic("La lista esta vacia")
+	}
+	return lista.primero.dato
+}
+
+func (lista *listaDoblementeEnlazada[T]) VerUltimo() T {
+	if lista.EstaVacia() {
+		panic("La lista esta vacia")
+	}
+	return lista.ultimo.dato
+}
+
+func (lista *listaDoblementeEnlazada[T]) Largo() int {
+	return lista.largo
+}
+
+func (lista *listaDoblementeEnlazada[T]) Iterar(visitar func(T) bool) {
+	for actual := lista.primero; actual != nil && visitar(actual.dato); actual = actual.siguiente {
+	}
+}
+
+func (lista *listaDoblementeEnlazada[T]) IterarAlReves(visitar func(T) bool) {
+	for actual := lista.ultimo; actual != nil && visitar(actual.dato); actual = actual.anterior {
+	}
+}
+
+func (lista *listaDoblementeEnlazada[T]) Todos() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		lista.Iterar(yield)
+	}
+}
+
+func (lista *listaDoblementeEnlazada[T]) TodosAlReves() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		lista.IterarAlReves(yield)
+	}
+}
+
+func (lista *listaDoblementeEnlazada[T]) Concatenar(otra ListaDoble[T]) {
+	origen := comoListaDoblementeEnlazada(otra)
+	if origen == lista {
+		panic("No se puede concatenar una lista consigo misma")
+	}
+	lista.empalmarAntesDe(origen, nil)
+}
+
+func (lista *listaDoblementeEnlazada[T]) Empalmar(iterador IteradorListaDoble[T], otra ListaDoble[T]) {
+	it, ok := iterador.(*iteradorListaDoble[T])
+	if !ok || it.lista != lista {
+		panic("El iterador no pertenece a la lista")
+	}
+	origen := comoListaDoblementeEnlazada(otra)
+	if origen == lista {
+		panic("No se puede empalmar una lista consigo misma")
+	}
+	it.actual = lista.empalmarAntesDe(origen, it.actual)
+}
+
+func (lista *listaDoblementeEnlazada[T]) Iterador() IteradorLista[T] {
+	return lista.IteradorDoble()
+}
+
+func (lista *listaDoblementeEnlazada[T]) IteradorDoble() IteradorListaDoble[T] {
+	return &iteradorListaDoble[T]{actual: lista.primero, lista: lista}
+}
+
+func (lista *listaDoblementeEnlazada[T]) IteradorAlFinal() IteradorListaDoble[T] {
+	return &iteradorListaDoble[T]{actual: lista.ultimo, lista: lista}
+}
+
+func (iter *iteradorListaDoble[T]) VerActual() T {
+	if !iter.HaySiguiente() {
+		panic("El iterador termino de iterar")
+	}
+	return iter.actual.dato
+}
+
+func (iter *iteradorListaDoble[T]) HaySiguiente() bool {
+	return iter.actual != nil
+}
+
+func (iter *iteradorListaDoble[T]) Siguiente() {
+	if !iter.HaySiguiente() {
+		panic("El iterador termino de iterar")
+	}
+	iter.actual = iter.actual.siguiente
+}
+
+func (iter *iteradorListaDoble[T]) HayAnterior() bool {
+	if iter.actual == nil {
+		return iter.lista.ultimo != nil
+	}
+	return iter.actual.anterior != nil
+}
+
+func (iter *iteradorListaDoble[T]) Anterior() {
+	if !iter.HayAnterior() {
+		panic("El iterador esta al principio")
+	}
+	if iter.actual == nil {
+		iter.actual = iter.lista.ultimo
+	} else {
+		iter.actual = iter.actual.anterior
+	}
+}
+
+func (iter *iteradorListaDoble[T]) Insertar(elemento T) {
+	nuevoNodo := &NodoListaDoble[T]{dato: elemento}
+	iter.lista.enlazarAntesDe(nuevoNodo, iter.actual)
+	iter.actual = nuevoNodo
+}
+
+func (iter *iteradorListaDoble[T]) Borrar() T {
+	if !iter.HaySiguiente() {
+		panic("El iterador termino de iterar")
+	}
+	borrado := iter.actual
+	iter.actual = borrado.siguiente
+	return iter.lista.desenlazar(borrado)
+}
@@ -1,5 +1,7 @@
 package lista
 
+import "iter"
+
 type nodo[T any] struct {
 	dato      T
 	siguiente *nodo[T]
@@ -94,6 +96,12 @@ func (lista *listaEnlazada[T]) Iterar(visitar func(T) bool) {
 	}
 }
 
+func (lista *listaEnlazada[T]) Todos() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		lista.Iterar(yield)
+	}
+}
+
 func (lista *listaEnlazada[T]) Iterador() IteradorLista[T] {
 	return &iteradorListaEnlazada[T]{
 		actual: lista.primero,
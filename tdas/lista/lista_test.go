@@ -503,3 +503,27 @@ func TestPruebaVolumen(t *testing.T) {
 		iter.Siguiente()
 	}
 }
+
+func TestRangeLista(t *testing.T) {
+	lista := TDALista.CrearListaEnlazada[int]()
+	for i := 1; i <= 5; i++ {
+		lista.InsertarUltimo(i)
+	}
+
+	// Recorrer con range va del primero al ultimo
+	recorridos := []int{}
+	for elem := range lista.Todos() {
+		recorridos = append(recorridos, elem)
+	}
+	require.Equal(t, []int{1, 2, 3, 4, 5}, recorridos, "Se espera recorrer la lista en orden")
+
+	// Cortar el ciclo con break deja de recorrer
+	recorridos = []int{}
+	for elem := range lista.Todos() {
+		if elem == 3 {
+			break
+		}
+		recorridos = append(recorridos, elem)
+	}
+	require.Equal(t, []int{1, 2}, recorridos, "Se espera que el break corte la iteracion")
+}
@@ -0,0 +1,178 @@
+package ordenamiento
+
+// Algoritmos de ordenamiento genericos. Todos ordenan el arreglo recibido in place de menor a mayor. Los
+// ordenamientos no comparativos (counting sort y radix sort) y merge sort son estables: los elementos
+// equivalentes conservan su orden relativo original.
+
+const (
+	BITS_DIGITO = 8
+	BASE        = 1 << BITS_DIGITO
+)
+
+// EnteroSinSigno agrupa a los tipos que se pueden ordenar con RadixSort
+type EnteroSinSigno interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// PRE:
+// POST: retorna el mensaje de error correspondiente al tipo proporcionado
+func mensajePanic(tipo string) string {
+	if tipo == "rango" {
+		return "La clave esta fuera de rango"
+	}
+	if tipo == "ancho" {
+		return "La clave no tiene el ancho indicado"
+	}
+	return "Error desconocido"
+}
+
+// PRE: origen y destino deben de tener el mismo largo y clave debe de devolver valores entre 0 y rango-1
+// POST: copia los elementos de origen en destino, ordenados de forma estable segun su clave
+func distribuir[T any](origen, destino []T, rango int, clave func(T) int) {
+	conteo := make([]int, rango+1)
+	for _, elemento := range origen {
+		conteo[clave(elemento)+1]++
+	}
+	// Cada posicion pasa a ser el indice de destino del primer elemento con esa clave
+	for i := 1; i <= rango; i++ {
+		conteo[i] += conteo[i-1]
+	}
+	for _, elemento := range origen {
+		c := clave(elemento)
+		destino[conteo[c]] = elemento
+		conteo[c]++
+	}
+}
+
+// CountingSort ordena de forma estable segun clave, que debe de devolver un entero entre 0 y rango-1. Cuesta
+// O(n + rango). Si alguna clave esta fuera de rango, entra en pánico con un mensaje 'La clave esta fuera de rango'.
+func CountingSort[T any](elementos []T, rango int, clave func(T) int) {
+	for _, elemento := range elementos {
+		if c := clave(elemento); c < 0 || c >= rango {
+			panic(mensajePanic("rango"))
+		}
+	}
+	aux := make([]T, len(elementos))
+	distribuir(elementos, aux, rango, clave)
+	copy(elementos, aux)
+}
+
+// RadixSort ordena enteros sin signo con radix sort LSD, aplicando counting sort a cada byte desde el menos
+// significativo. Solo recorre los bytes necesarios para el mayor elemento, por lo que cuesta O(n * bytes).
+func RadixSort[T EnteroSinSigno](elementos []T) {
+	if len(elementos) < 2 {
+		return
+	}
+	maximo := elementos[0]
+	for _, elemento := range elementos {
+		maximo = max(maximo, elemento)
+	}
+	origen, destino := elementos, make([]T, len(elementos))
+	for desplazamiento := 0; maximo>>desplazamiento > 0; desplazamiento += BITS_DIGITO {
+		distribuir(origen, destino, BASE, func(elemento T) int {
+			return int(elemento>>desplazamiento) & (BASE - 1)
+		})
+		origen, destino = destino, origen
+	}
+	// Tras una cantidad impar de pasadas el resultado quedo en el arreglo auxiliar
+	if &origen[0] != &elementos[0] {
+		copy(elementos, origen)
+	}
+}
+
+type elementoConClave[T any] struct {
+	elemento T
+	clave    []byte
+}
+
+// RadixSortClaves ordena con radix sort LSD segun claves de ancho fijo, comparadas byte a byte como en
+// bytes.Compare (por ejemplo, una IP en 4 bytes o una fecha en formato "AAAAMMDD"). La clave de cada elemento se
+// calcula una sola vez. Si alguna clave no tiene exactamente ancho bytes, entra en pánico con un mensaje
+// 'La clave no tiene el ancho indicado'.
+func RadixSortClaves[T any](elementos []T, ancho int, clave func(T) []byte) {
+	origen := make([]elementoConClave[T], len(elementos))
+	for i, elemento := range elementos {
+		origen[i] = elementoConClave[T]{elemento, clave(elemento)}
+		if len(origen[i].clave) != ancho {
+			panic(mensajePanic("ancho"))
+		}
+	}
+	destino := make([]elementoConClave[T], len(elementos))
+	for posicion := ancho - 1; posicion >= 0; posicion-- {
+		distribuir(origen, destino, BASE, func(e elementoConClave[T]) int {
+			return int(e.clave[posicion])
+		})
+		origen, destino = destino, origen
+	}
+	for i := range origen {
+		elementos[i] = origen[i].elemento
+	}
+}
+
+// MergeSort ordena de forma estable segun funcion_cmp, en O(n log n) usando un arreglo auxiliar de largo n.
+func MergeSort[T any](elementos []T, funcion_cmp func(T, T) int) {
+	aux := make([]T, len(elementos))
+	mergeSortRecursivo(elementos, aux, funcion_cmp)
+}
+
+// PRE: aux debe de tener el mismo largo que elementos
+// POST: ordena elementos, usando aux como espacio de trabajo
+func mergeSortRecursivo[T any](elementos, aux []T, funcion_cmp func(T, T) int) {
+	if len(elementos) < 2 {
+		return
+	}
+	medio := len(elementos) / 2
+	mergeSortRecursivo(elementos[:medio], aux[:medio], funcion_cmp)
+	mergeSortRecursivo(elementos[medio:], aux[medio:], funcion_cmp)
+	// Si las mitades ya estan en orden no hace falta intercalarlas
+	if funcion_cmp(elementos[medio-1], elementos[medio]) <= 0 {
+		return
+	}
+	copy(aux, elementos)
+	intercalar(aux[:medio], aux[medio:], elementos, funcion_cmp)
+}
+
+// PRE: izquierda y derecha deben de estar ordenados y destino debe de tener lugar para ambos
+// POST: intercala ambas mitades en destino. Ante empates toma primero de izquierda, lo que hace estable al ordenamiento
+func intercalar[T any](izquierda, derecha, destino []T, funcion_cmp func(T, T) int) {
+	i, j := 0, 0
+	for k := range destino {
+		if j == len(derecha) || (i < len(izquierda) && funcion_cmp(izquierda[i], derecha[j]) <= 0) {
+			destino[k] = izquierda[i]
+			i++
+		} else {
+			destino[k] = derecha[j]
+			j++
+		}
+	}
+}
+
+// HeapSort ordena segun funcion_cmp in place en O(n log n), sin memoria adicional: arma un heap de maximos sobre el
+// mismo arreglo y mueve el maximo al final de la parte sin ordenar. No es estable.
+func HeapSort[T any](elementos []T, funcion_cmp func(T, T) int) {
+	for i := len(elementos)/2 - 1; i >= 0; i-- {
+		hundir(elementos, i, funcion_cmp)
+	}
+	for fin := len(elementos) - 1; fin > 0; fin-- {
+		elementos[0], elementos[fin] = elementos[fin], elementos[0]
+		hundir(elementos[:fin], 0, funcion_cmp)
+	}
+}
+
+// PRE: heap debe de cumplir la propiedad de heap de maximos, excepto por el elemento en la posicion i
+// POST: baja el elemento de la posicion i intercambiandolo con su hijo mayor hasta restaurar la propiedad de heap
+func hundir[T any](heap []T, i int, funcion_cmp func(T, T) int) {
+	for {
+		mayor := i
+		for _, hijo := range [2]int{2*i + 1, 2*i + 2} {
+			if hijo < len(heap) && funcion_cmp(heap[hijo], heap[mayor]) > 0 {
+				mayor = hijo
+			}
+		}
+		if mayor == i {
+			return
+		}
+		heap[i], heap[mayor] = heap[mayor], heap[i]
+		i = mayor
+	}
+}
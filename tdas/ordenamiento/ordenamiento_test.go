@@ -0,0 +1,186 @@
+package ordenamiento_test
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/binary"
+	"math/rand"
+	"slices"
+	TDAOrdenamiento "tdas/ordenamiento"
+	"testing"
+	"testing/quick"
+
+	"github.com/stretchr/testify/require"
+)
+
+// registro permite verificar la estabilidad: clave es por lo que se ordena y posicion es el orden original
+type registro struct {
+	clave    uint8
+	posicion int
+}
+
+func cmpRegistros(a, b registro) int {
+	return cmp.Compare(a.clave, b.clave)
+}
+
+// registros arma un arreglo con claves repetidas a partir de los bytes generados
+func registros(claves []uint8) []registro {
+	resultado := make([]registro, len(claves))
+	for i, clave := range claves {
+		resultado[i] = registro{clave % 16, i}
+	}
+	return resultado
+}
+
+// esOrdenEstable verifica que el arreglo este ordenado por clave y que a igual clave se mantenga el orden original
+func esOrdenEstable(ordenados []registro) bool {
+	for i := 1; i < len(ordenados); i++ {
+		anterior, actual := ordenados[i-1], ordenados[i]
+		if anterior.clave > actual.clave || (anterior.clave == actual.clave && anterior.posicion > actual.posicion) {
+			return false
+		}
+	}
+	return true
+}
+
+// esPermutacion verifica que ordenados tenga los mismos elementos que originales
+func esPermutacion[T cmp.Ordered](originales, ordenados []T) bool {
+	a, b := slices.Clone(originales), slices.Clone(ordenados)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
+
+var configuracion = &quick.Config{MaxCount: 500, Rand: rand.New(rand.NewSource(38))}
+
+func TestRadixSortPropiedades(t *testing.T) {
+	require.NoError(t, quick.Check(func(originales []uint32) bool {
+		elementos := slices.Clone(originales)
+		TDAOrdenamiento.RadixSort(elementos)
+		return slices.IsSorted(elementos) && esPermutacion(originales, elementos)
+	}, configuracion))
+	require.NoError(t, quick.Check(func(originales []uint64) bool {
+		elementos := slices.Clone(originales)
+		TDAOrdenamiento.RadixSort(elementos)
+		return slices.IsSorted(elementos) && esPermutacion(originales, elementos)
+	}, configuracion))
+	require.NoError(t, quick.Check(func(originales []uint8) bool {
+		elementos := slices.Clone(originales)
+		TDAOrdenamiento.RadixSort(elementos)
+		return slices.IsSorted(elementos) && esPermutacion(originales, elementos)
+	}, configuracion))
+}
+
+func TestRadixSortCasosBorde(t *testing.T) {
+	var vacio []uint32
+	TDAOrdenamiento.RadixSort(vacio)
+	require.Empty(t, vacio)
+
+	ceros := []uint16{0, 0, 0}
+	TDAOrdenamiento.RadixSort(ceros)
+	require.Equal(t, []uint16{0, 0, 0}, ceros)
+
+	// Con una cantidad impar de bytes significativos el resultado queda en el arreglo auxiliar y debe copiarse
+	impar := []uint32{0x10000, 3, 0xFFFFFF, 1}
+	TDAOrdenamiento.RadixSort(impar)
+	require.Equal(t, []uint32{1, 3, 0x10000, 0xFFFFFF}, impar)
+
+	extremos := []uint64{^uint64(0), 0, 1 << 63, 1}
+	TDAOrdenamiento.RadixSort(extremos)
+	require.Equal(t, []uint64{0, 1, 1 << 63, ^uint64(0)}, extremos)
+}
+
+func TestRadixSortClavesPropiedades(t *testing.T) {
+	clave := func(numero uint32) []byte {
+		return binary.BigEndian.AppendUint32(nil, numero)
+	}
+	require.NoError(t, quick.Check(func(originales []uint32) bool {
+		elementos := slices.Clone(originales)
+		TDAOrdenamiento.RadixSortClaves(elementos, 4, clave)
+		return slices.IsSorted(elementos) && esPermutacion(originales, elementos)
+	}, configuracion))
+
+	require.NoError(t, quick.Check(func(claves []uint8) bool {
+		elementos := registros(claves)
+		TDAOrdenamiento.RadixSortClaves(elementos, 1, func(r registro) []byte { return []byte{r.clave} })
+		return esOrdenEstable(elementos)
+	}, configuracion))
+}
+
+func TestRadixSortClavesTexto(t *testing.T) {
+	fechas := []string{"20150517", "20141231", "20150101", "20150517", "19991231"}
+	TDAOrdenamiento.RadixSortClaves(fechas, 8, func(fecha string) []byte { return []byte(fecha) })
+	require.True(t, slices.IsSortedFunc(fechas, func(a, b string) int { return bytes.Compare([]byte(a), []byte(b)) }))
+
+	require.PanicsWithValue(t, "La clave no tiene el ancho indicado", func() {
+		TDAOrdenamiento.RadixSortClaves([]string{"abc", "ab"}, 3, func(s string) []byte { return []byte(s) })
+	})
+}
+
+func TestCountingSortPropiedades(t *testing.T) {
+	require.NoError(t, quick.Check(func(claves []uint8) bool {
+		elementos := registros(claves)
+		TDAOrdenamiento.CountingSort(elementos, 16, func(r registro) int { return int(r.clave) })
+		return esOrdenEstable(elementos) && len(elementos) == len(claves)
+	}, configuracion))
+}
+
+func TestCountingSortFueraDeRango(t *testing.T) {
+	elementos := []int{3, 1, 7}
+	require.PanicsWithValue(t, "La clave esta fuera de rango", func() {
+		TDAOrdenamiento.CountingSort(elementos, 5, func(n int) int { return n })
+	})
+	require.Equal(t, []int{3, 1, 7}, elementos, "Ante una clave invalida no se deberia modificar el arreglo")
+	require.PanicsWithValue(t, "La clave esta fuera de rango", func() {
+		TDAOrdenamiento.CountingSort([]int{-1}, 5, func(n int) int { return n })
+	})
+}
+
+func TestMergeSortPropiedades(t *testing.T) {
+	require.NoError(t, quick.Check(func(originales []int) bool {
+		elementos := slices.Clone(originales)
+		TDAOrdenamiento.MergeSort(elementos, cmp.Compare[int])
+		return slices.IsSorted(elementos) && esPermutacion(originales, elementos)
+	}, configuracion))
+	require.NoError(t, quick.Check(func(claves []uint8) bool {
+		elementos := registros(claves)
+		TDAOrdenamiento.MergeSort(elementos, cmpRegistros)
+		return esOrdenEstable(elementos)
+	}, configuracion))
+}
+
+func TestMergeSortComparadorInvertido(t *testing.T) {
+	elementos := []string{"b", "d", "a", "c"}
+	TDAOrdenamiento.MergeSort(elementos, func(a, b string) int { return cmp.Compare(b, a) })
+	require.Equal(t, []string{"d", "c", "b", "a"}, elementos)
+}
+
+func TestHeapSortPropiedades(t *testing.T) {
+	require.NoError(t, quick.Check(func(originales []int) bool {
+		elementos := slices.Clone(originales)
+		TDAOrdenamiento.HeapSort(elementos, cmp.Compare[int])
+		return slices.IsSorted(elementos) && esPermutacion(originales, elementos)
+	}, configuracion))
+}
+
+func TestOrdenamientosCoincidenEnVolumen(t *testing.T) {
+	generador := rand.New(rand.NewSource(380))
+	originales := make([]uint32, 200000)
+	for i := range originales {
+		originales[i] = generador.Uint32()
+	}
+	esperado := slices.Clone(originales)
+	slices.Sort(esperado)
+
+	radix := slices.Clone(originales)
+	TDAOrdenamiento.RadixSort(radix)
+	require.Equal(t, esperado, radix)
+
+	merge := slices.Clone(originales)
+	TDAOrdenamiento.MergeSort(merge, cmp.Compare[uint32])
+	require.Equal(t, esperado, merge)
+
+	heap := slices.Clone(originales)
+	TDAOrdenamiento.HeapSort(heap, cmp.Compare[uint32])
+	require.Equal(t, esperado, heap)
+}
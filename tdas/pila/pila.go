@@ -1,5 +1,7 @@
 package pila
 
+import "iter"
+
 type Pila[T any] interface {
 
 	// EstaVacia devuelve verdadero si la pila no tiene elementos apilados, false en caso contrario.
@@ -15,4 +17,8 @@ type Pila[T any] interface {
 	// Desapilar saca el elemento tope de la pila. Si la pila tiene elementos, se quita el tope de la pila, y
 	// se devuelve ese valor. Si está vacía, entra en pánico con un mensaje "La pila esta vacia".
 	Desapilar() T
+
+	// Todos devuelve un iter.Seq que recorre los elementos de la pila desde el tope hasta el fondo, sin
+	// desapilarlos.
+	Todos() iter.Seq[T]
 }
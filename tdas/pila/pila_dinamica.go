@@ -1,5 +1,7 @@
 package pila
 
+import "iter"
+
 const (
 	TAMANIO_INICIAL   int = 4
 	FACTOR_EXPANSION  int = 2
@@ -78,3 +80,15 @@ func (p *pilaDinamica[T]) Desapilar() T {
 	}
 	return tope
 }
+
+// PRE:
+// POST: retorna un iter.Seq que recorre los elementos desde el tope hasta el fondo sin modificar la pila
+func (p *pilaDinamica[T]) Todos() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := p.cantidad - 1; i >= 0; i-- {
+			if !yield(p.datos[i]) {
+				return
+			}
+		}
+	}
+}
@@ -136,3 +136,28 @@ func TestVolumenPila(t *testing.T) {
 
 	require.True(t, pila.EstaVacia(), "La pila deberia estar vacia despues de desapilar todos los elementos")
 }
+
+func TestRangePila(t *testing.T) {
+	pila := TDAPila.CrearPilaDinamica[int]()
+	for i := 1; i <= 5; i++ {
+		pila.Apilar(i)
+	}
+
+	// Recorrer con range va del tope al fondo sin desapilar
+	recorridos := []int{}
+	for elem := range pila.Todos() {
+		recorridos = append(recorridos, elem)
+	}
+	require.Equal(t, []int{5, 4, 3, 2, 1}, recorridos, "Se espera recorrer la pila desde el tope")
+	require.Equal(t, 5, pila.VerTope(), "Recorrer la pila no deberia modificarla")
+
+	// Cortar el ciclo con break deja de recorrer
+	recorridos = []int{}
+	for elem := range pila.Todos() {
+		if elem == 3 {
+			break
+		}
+		recorridos = append(recorridos, elem)
+	}
+	require.Equal(t, []int{5, 4}, recorridos, "Se espera que el break corte la iteracion")
+}
@@ -0,0 +1,107 @@
+package top_k
+
+import TDAHEAP "tdas/cola_prioridad"
+
+// Implementacion del algoritmo Space-Saving (Metwally, Agrawal y El Abbadi, 2005):
+// https://www.cs.ucsb.edu/sites/default/files/documents/2005-23.pdf
+//
+// Se monitorean a lo sumo `capacidad` elementos. Cuando llega uno nuevo y no hay lugar, reemplaza al de menor
+// conteo, heredando ese conteo como error. El heap indexado se ordena de menor a mayor conteo para encontrar y
+// actualizar al minimo en O(log m).
+
+type contador struct {
+	conteo   int
+	errorMax int
+}
+
+type spaceSaving[K comparable] struct {
+	monitoreados TDAHEAP.ColaPrioridadIndexada[K, contador]
+	capacidad    int
+	total        int
+}
+
+// PRE: c1 y c2 son contadores inicializados.
+// POST: compara contadores por conteo en orden ascendente, para que el heap tenga al menor en el tope
+func compararContadores(c1, c2 contador) int {
+	return c2.conteo - c1.conteo
+}
+
+// PRE: la capacidad debe de ser mayor a 0
+// POST: crea un TopK que monitorea a lo sumo `capacidad` elementos
+func CrearSpaceSaving[K comparable](capacidad int) TopK[K] {
+	if capacidad <= 0 {
+		panic("La capacidad debe ser mayor a 0")
+	}
+	return &spaceSaving[K]{
+		monitoreados: TDAHEAP.CrearHeapIndexado[K, contador](compararContadores),
+		capacidad:    capacidad,
+	}
+}
+
+func (s *spaceSaving[K]) Agregar(clave K) {
+	s.AgregarN(clave, 1)
+}
+
+// Un elemento nuevo que reemplaza al minimo hereda su conteo como error, igual que al agregarlo de a uno: las n
+// apariciones seguidas lo mantendrian monitoreado, ya que despues de la primera deja de ser el minimo
+func (s *spaceSaving[K]) AgregarN(clave K, n int) {
+	if n <= 0 {
+		panic("La cantidad debe ser mayor a 0")
+	}
+	s.total += n
+	if s.monitoreados.Contiene(clave) {
+		actual := s.monitoreados.Obtener(clave)
+		s.monitoreados.ActualizarPrioridad(clave, contador{conteo: actual.conteo + n, errorMax: actual.errorMax})
+		return
+	}
+	if s.monitoreados.Cantidad() < s.capacidad {
+		s.monitoreados.Encolar(clave, contador{conteo: n})
+		return
+	}
+	_, minimo := s.monitoreados.Desencolar()
+	s.monitoreados.Encolar(clave, contador{conteo: minimo.conteo + n, errorMax: minimo.conteo})
+}
+
+func (s *spaceSaving[K]) Estimar(clave K) (int, int) {
+	if s.monitoreados.Contiene(clave) {
+		actual := s.monitoreados.Obtener(clave)
+		return actual.conteo, actual.errorMax
+	}
+	if s.monitoreados.Cantidad() < s.capacidad {
+		return 0, 0
+	}
+	_, minimo := s.monitoreados.VerMax()
+	return minimo.conteo, minimo.conteo
+}
+
+// Como el heap solo permite ver al minimo, se desencolan todos los monitoreados (de menor a mayor) y se vuelven
+// a encolar. Esto cuesta O(m log m), que no depende del tamaño del flujo.
+func (s *spaceSaving[K]) IterarMayores(n int, visitar func(clave K, conteo int, errorMax int) bool) {
+	cantidad := s.monitoreados.Cantidad()
+	claves := make([]K, cantidad)
+	contadores := make([]contador, cantidad)
+	for i := 0; i < cantidad; i++ {
+		claves[i], contadores[i] = s.monitoreados.Desencolar()
+	}
+	for i := 0; i < cantidad; i++ {
+		s.monitoreados.Encolar(claves[i], contadores[i])
+	}
+
+	for i := cantidad - 1; i >= 0 && cantidad-i <= n; i-- {
+		if !visitar(claves[i], contadores[i].conteo, contadores[i].errorMax) {
+			return
+		}
+	}
+}
+
+func (s *spaceSaving[K]) Cantidad() int {
+	return s.monitoreados.Cantidad()
+}
+
+func (s *spaceSaving[K]) Total() int {
+	return s.total
+}
+
+func (s *spaceSaving[K]) ErrorMaximo() int {
+	return s.total / s.capacidad
+}
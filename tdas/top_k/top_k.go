@@ -0,0 +1,37 @@
+package top_k
+
+// TopK lleva la cuenta aproximada de los elementos mas frecuentes de un flujo, usando memoria acotada por
+// la capacidad con la que fue creado, sin importar cuantos elementos distintos aparezcan.
+//
+// Si N es la cantidad total de elementos agregados y m la capacidad, se garantiza que:
+//   - el conteo reportado de un elemento nunca es menor a su frecuencia real, y la excede en a lo sumo su error.
+//   - el error de cualquier elemento es a lo sumo N/m.
+//   - todo elemento con frecuencia real mayor a N/m se encuentra entre los monitoreados.
+type TopK[K comparable] interface {
+
+	// Agregar registra una aparicion del elemento en el flujo.
+	Agregar(clave K)
+
+	// AgregarN registra n apariciones del elemento en O(log m), igual que n llamadas a Agregar seguidas. Las
+	// garantias se mantienen tomando a N como la suma de todas las apariciones agregadas. Si n no es mayor a 0,
+	// entra en pánico con un mensaje "La cantidad debe ser mayor a 0".
+	AgregarN(clave K, n int)
+
+	// Estimar devuelve el conteo estimado de un elemento y el error maximo de dicha estimacion. Si el elemento no
+	// esta monitoreado, su frecuencia real es a lo sumo el menor conteo monitoreado, que se devuelve como conteo
+	// y como error.
+	Estimar(clave K) (conteo int, errorMax int)
+
+	// IterarMayores recorre los n elementos monitoreados con mayor conteo, de mayor a menor, aplicando la funcion
+	// visitar hasta que devuelva false. En caso de empate se pueden recorrer en cualquier orden.
+	IterarMayores(n int, visitar func(clave K, conteo int, errorMax int) bool)
+
+	// Cantidad devuelve la cantidad de elementos monitoreados, que nunca supera a la capacidad.
+	Cantidad() int
+
+	// Total devuelve la cantidad de elementos agregados al flujo.
+	Total() int
+
+	// ErrorMaximo devuelve la cota N/m del error de cualquier estimacion.
+	ErrorMaximo() int
+}
@@ -0,0 +1,170 @@
+package top_k_test
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+	TDATopK "tdas/top_k"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Logs de volumen de las pruebas del TP, usados para comparar contra los conteos exactos
+const RUTA_LOGS_VOLUMEN = "../../tp2/pruebas_analog/volumen0*.log"
+
+func TestTopKVacio(t *testing.T) {
+	topK := TDATopK.CrearSpaceSaving[string](3)
+	require.Equal(t, 0, topK.Cantidad(), "Un TopK recien creado no monitorea elementos")
+	require.Equal(t, 0, topK.Total(), "Un TopK recien creado no tiene elementos agregados")
+	conteo, errorMax := topK.Estimar("/")
+	require.Equal(t, 0, conteo)
+	require.Equal(t, 0, errorMax)
+	topK.IterarMayores(10, func(clave string, conteo, errorMax int) bool {
+		require.Fail(t, "No deberia haber elementos para recorrer")
+		return true
+	})
+	require.Panics(t, func() { TDATopK.CrearSpaceSaving[string](0) }, "La capacidad debe ser positiva")
+}
+
+func TestTopKExactoConCapacidadSuficiente(t *testing.T) {
+	topK := TDATopK.CrearSpaceSaving[string](10)
+	for _, recurso := range []string{"/a", "/b", "/a", "/c", "/a", "/b"} {
+		topK.Agregar(recurso)
+	}
+
+	// Mientras no se supere la capacidad, los conteos son exactos
+	claves, conteos := []string{}, []int{}
+	topK.IterarMayores(2, func(clave string, conteo, errorMax int) bool {
+		require.Equal(t, 0, errorMax, "Sin reemplazos no deberia haber error")
+		claves = append(claves, clave)
+		conteos = append(conteos, conteo)
+		return true
+	})
+	require.Equal(t, []string{"/a", "/b"}, claves, "Se esperan los dos recursos mas frecuentes en orden")
+	require.Equal(t, []int{3, 2}, conteos)
+	require.Equal(t, 3, topK.Cantidad())
+	require.Equal(t, 6, topK.Total())
+}
+
+func TestTopKReemplazaAlMinimo(t *testing.T) {
+	topK := TDATopK.CrearSpaceSaving[string](2)
+	for _, recurso := range []string{"/a", "/a", "/a", "/b", "/c"} {
+		topK.Agregar(recurso)
+	}
+
+	// "/c" reemplaza a "/b", heredando su conteo como error
+	require.Equal(t, 2, topK.Cantidad(), "No se deben monitorear mas elementos que la capacidad")
+	conteo, errorMax := topK.Estimar("/c")
+	require.Equal(t, 2, conteo)
+	require.Equal(t, 1, errorMax)
+
+	// "/b" ya no esta monitoreado, su frecuencia real es a lo sumo el minimo monitoreado
+	conteo, errorMax = topK.Estimar("/b")
+	require.Equal(t, 2, conteo)
+	require.Equal(t, 2, errorMax)
+
+	conteo, errorMax = topK.Estimar("/a")
+	require.Equal(t, 3, conteo)
+	require.Equal(t, 0, errorMax)
+}
+
+func TestTopKAgregarN(t *testing.T) {
+	// Agregar n apariciones seguidas equivale a agregarlas de a una
+	deAUno := TDATopK.CrearSpaceSaving[string](2)
+	enBloque := TDATopK.CrearSpaceSaving[string](2)
+	for _, paso := range []struct {
+		recurso string
+		n       int
+	}{{"/a", 5}, {"/b", 2}, {"/c", 3}, {"/a", 1}, {"/d", 4}} {
+		for range paso.n {
+			deAUno.Agregar(paso.recurso)
+		}
+		enBloque.AgregarN(paso.recurso, paso.n)
+	}
+	require.Equal(t, deAUno.Total(), enBloque.Total())
+	for _, recurso := range []string{"/a", "/b", "/c", "/d"} {
+		conteo, errorMax := deAUno.Estimar(recurso)
+		conteoN, errorMaxN := enBloque.Estimar(recurso)
+		require.Equal(t, conteo, conteoN, "Conteo de %s", recurso)
+		require.Equal(t, errorMax, errorMaxN, "Error de %s", recurso)
+	}
+	require.PanicsWithValue(t, "La cantidad debe ser mayor a 0", func() { enBloque.AgregarN("/a", 0) })
+}
+
+func TestTopKCorteDeIteracion(t *testing.T) {
+	topK := TDATopK.CrearSpaceSaving[int](100)
+	for i := 0; i < 50; i++ {
+		for j := 0; j <= i; j++ {
+			topK.Agregar(i)
+		}
+	}
+	recorridos := []int{}
+	topK.IterarMayores(10, func(clave int, conteo, errorMax int) bool {
+		recorridos = append(recorridos, clave)
+		return clave != 47
+	})
+	require.Equal(t, []int{49, 48, 47}, recorridos, "Se espera cortar la iteracion cuando visitar devuelve false")
+
+	// Iterar no pierde elementos monitoreados
+	require.Equal(t, 50, topK.Cantidad())
+}
+
+// Carga los recursos de los logs de volumen en un TopK con poca capacidad y verifica las cotas de error
+// contra los conteos exactos.
+func TestTopKContraConteoExacto(t *testing.T) {
+	archivos, _ := filepath.Glob(RUTA_LOGS_VOLUMEN)
+	if len(archivos) == 0 {
+		t.Skip("No se encontraron los logs de volumen")
+	}
+
+	const capacidad = 50
+	topK := TDATopK.CrearSpaceSaving[string](capacidad)
+	exactos := make(map[string]int)
+	for _, archivo := range archivos {
+		file, err := os.Open(archivo)
+		require.NoError(t, err)
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			campos := strings.Split(scanner.Text(), "\t")
+			if len(campos) < 4 {
+				continue
+			}
+			topK.Agregar(campos[3])
+			exactos[campos[3]]++
+		}
+		file.Close()
+	}
+	require.Greater(t, len(exactos), capacidad, "La prueba necesita mas recursos distintos que la capacidad")
+
+	cota := topK.ErrorMaximo()
+	require.Equal(t, topK.Total()/capacidad, cota)
+
+	for recurso, real := range exactos {
+		conteo, errorMax := topK.Estimar(recurso)
+		require.GreaterOrEqual(t, conteo, real, "El conteo estimado de %s no puede ser menor al real", recurso)
+		require.LessOrEqual(t, conteo-errorMax, real, "El conteo de %s excede al real en mas que su error", recurso)
+		require.LessOrEqual(t, errorMax, cota, "El error de %s supera la cota N/m", recurso)
+	}
+
+	// Todo recurso con frecuencia mayor a N/m tiene que estar entre los monitoreados
+	monitoreados := make(map[string]bool)
+	topK.IterarMayores(capacidad, func(recurso string, conteo, errorMax int) bool {
+		monitoreados[recurso] = true
+		return true
+	})
+	for recurso, real := range exactos {
+		if real > cota {
+			require.True(t, monitoreados[recurso], "El recurso frecuente %s deberia estar monitoreado", recurso)
+		}
+	}
+
+	// Los recursos mas visitados de los logs tienen frecuencias bien separadas, asi que coinciden con los exactos
+	mayores := []string{}
+	topK.IterarMayores(3, func(recurso string, conteo, errorMax int) bool {
+		mayores = append(mayores, recurso)
+		return true
+	})
+	require.Equal(t, []string{"/favicon.ico", "/", "/style2.css"}, mayores)
+}
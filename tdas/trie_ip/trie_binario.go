@@ -0,0 +1,165 @@
+package trie_ip
+
+// Trie binario: cada nivel corresponde a un bit de la direccion, empezando por el mas significativo, por lo que
+// todas las operaciones recorren a lo sumo 32 nodos.
+
+const BITS_IP = 32
+
+type nodoTrie[V any] struct {
+	hijos     [2]*nodoTrie[V]
+	dato      V
+	tieneDato bool
+}
+
+type trieBinario[V any] struct {
+	raiz     *nodoTrie[V]
+	cantidad int
+}
+
+// PRE:
+// POST: retorna el mensaje de error correspondiente al tipo proporcionado
+func mensajePanic(tipo string) string {
+	if tipo == "prefijo" {
+		return "El prefijo no pertenece al trie"
+	}
+	if tipo == "largo" {
+		return "Largo de prefijo invalido"
+	}
+	return "Error desconocido"
+}
+
+func CrearTrieIP[V any]() TrieIP[V] {
+	return &trieBinario[V]{raiz: &nodoTrie[V]{}}
+}
+
+// PRE: i debe de estar entre 0 y 31
+// POST: retorna el i-esimo bit de la direccion, contando desde el mas significativo
+func bit(direccion uint32, i int) int {
+	return int(direccion>>(BITS_IP-1-i)) & 1
+}
+
+// PRE:
+// POST: entra en panico si el largo no esta entre 0 y 32
+func validarLargo(largo int) {
+	if largo < 0 || largo > BITS_IP {
+		panic(mensajePanic("largo"))
+	}
+}
+
+// PRE: el largo debe de ser valido
+// POST: retorna el nodo del prefijo, creando los nodos intermedios si crear es true. Si no existe y no se
+// crea, retorna nil
+func (t *trieBinario[V]) buscarNodo(prefijo uint32, largo int, crear bool) *nodoTrie[V] {
+	nodo := t.raiz
+	for i := 0; i < largo && nodo != nil; i++ {
+		b := bit(prefijo, i)
+		if nodo.hijos[b] == nil && crear {
+			nodo.hijos[b] = &nodoTrie[V]{}
+		}
+		nodo = nodo.hijos[b]
+	}
+	return nodo
+}
+
+func (t *trieBinario[V]) Guardar(prefijo uint32, largo int, dato V) {
+	validarLargo(largo)
+	nodo := t.buscarNodo(prefijo, largo, true)
+	if !nodo.tieneDato {
+		t.cantidad++
+		nodo.tieneDato = true
+	}
+	nodo.dato = dato
+}
+
+func (t *trieBinario[V]) Pertenece(prefijo uint32, largo int) bool {
+	validarLargo(largo)
+	nodo := t.buscarNodo(prefijo, largo, false)
+	return nodo != nil && nodo.tieneDato
+}
+
+func (t *trieBinario[V]) Obtener(prefijo uint32, largo int) V {
+	validarLargo(largo)
+	nodo := t.buscarNodo(prefijo, largo, false)
+	if nodo == nil || !nodo.tieneDato {
+		panic(mensajePanic("prefijo"))
+	}
+	return nodo.dato
+}
+
+// PRE: nodo no debe de ser nil y nivel debe de ser la profundidad del nodo
+// POST: borra el prefijo del subarbol y retorna el nodo actualizado (nil si quedo sin datos ni hijos) y el dato borrado
+func (t *trieBinario[V]) borrarNodo(nodo *nodoTrie[V], prefijo uint32, largo, nivel int) (*nodoTrie[V], V) {
+	var dato V
+	if nivel == largo {
+		if !nodo.tieneDato {
+			panic(mensajePanic("prefijo"))
+		}
+		var vacio V
+		dato, nodo.dato, nodo.tieneDato = nodo.dato, vacio, false
+		t.cantidad--
+	} else {
+		b := bit(prefijo, nivel)
+		if nodo.hijos[b] == nil {
+			panic(mensajePanic("prefijo"))
+		}
+		nodo.hijos[b], dato = t.borrarNodo(nodo.hijos[b], prefijo, largo, nivel+1)
+	}
+	if nivel > 0 && !nodo.tieneDato && nodo.hijos[0] == nil && nodo.hijos[1] == nil {
+		return nil, dato
+	}
+	return nodo, dato
+}
+
+func (t *trieBinario[V]) Borrar(prefijo uint32, largo int) V {
+	validarLargo(largo)
+	var dato V
+	t.raiz, dato = t.borrarNodo(t.raiz, prefijo, largo, 0)
+	return dato
+}
+
+func (t *trieBinario[V]) Cantidad() int {
+	return t.cantidad
+}
+
+func (t *trieBinario[V]) BuscarMasEspecifico(ip uint32) (uint32, int, V, bool) {
+	var dato V
+	largo, encontrado := 0, false
+	nodo := t.raiz
+	for i := 0; nodo != nil; i++ {
+		if nodo.tieneDato {
+			dato, largo, encontrado = nodo.dato, i, true
+		}
+		if i == BITS_IP {
+			break
+		}
+		nodo = nodo.hijos[bit(ip, i)]
+	}
+	return mascara(ip, largo), largo, dato, encontrado
+}
+
+// PRE: el largo debe de ser valido
+// POST: retorna la direccion con los bits fuera del prefijo en 0
+func mascara(direccion uint32, largo int) uint32 {
+	if largo == 0 {
+		return 0
+	}
+	return direccion &^ (1<<(BITS_IP-largo) - 1)
+}
+
+// PRE: nodo no debe de ser nil, prefijo y nivel deben de corresponder al nodo
+// POST: recorre en preorden el subarbol, devolviendo false si se corto la iteracion
+func (t *trieBinario[V]) iterarNodo(nodo *nodoTrie[V], prefijo uint32, nivel int, visitar func(uint32, int, V) bool) bool {
+	if nodo.tieneDato && !visitar(prefijo, nivel, nodo.dato) {
+		return false
+	}
+	for b, hijo := range nodo.hijos {
+		if hijo != nil && !t.iterarNodo(hijo, prefijo|uint32(b)<<(BITS_IP-1-nivel), nivel+1, visitar) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *trieBinario[V]) Iterar(visitar func(prefijo uint32, largo int, dato V) bool) {
+	t.iterarNodo(t.raiz, 0, 0, visitar)
+}
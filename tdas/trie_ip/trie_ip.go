@@ -0,0 +1,33 @@
+package trie_ip
+
+// TrieIP asocia datos a prefijos de direcciones IPv4 (redes en notacion CIDR, como 10.0.0.0/8), y permite
+// encontrar el prefijo mas especifico que contiene a una IP. Las IPs y prefijos se representan como uint32 y
+// los bits que quedan fuera del largo del prefijo se ignoran.
+type TrieIP[V any] interface {
+
+	// Guardar guarda el dato asociado al prefijo. Si el prefijo ya se encontraba, se actualiza el dato. Si el largo
+	// no esta entre 0 y 32, entra en pánico con un mensaje 'Largo de prefijo invalido'.
+	Guardar(prefijo uint32, largo int, dato V)
+
+	// Pertenece determina si el prefijo se encuentra guardado.
+	Pertenece(prefijo uint32, largo int) bool
+
+	// Obtener devuelve el dato asociado al prefijo. Si el prefijo no pertenece, entra en pánico con un mensaje
+	// 'El prefijo no pertenece al trie'.
+	Obtener(prefijo uint32, largo int) V
+
+	// Borrar borra el prefijo, devolviendo su dato. Si el prefijo no pertenece, entra en pánico con un mensaje
+	// 'El prefijo no pertenece al trie'.
+	Borrar(prefijo uint32, largo int) V
+
+	// Cantidad devuelve la cantidad de prefijos guardados.
+	Cantidad() int
+
+	// BuscarMasEspecifico devuelve el prefijo de mayor largo que contiene a la IP, junto con su dato. Si ningun
+	// prefijo la contiene, encontrado es false.
+	BuscarMasEspecifico(ip uint32) (prefijo uint32, largo int, dato V, encontrado bool)
+
+	// Iterar recorre los prefijos guardados en orden (de menor a mayor direccion, y a igual direccion del menos
+	// especifico al mas especifico), aplicando visitar mientras devuelva true.
+	Iterar(visitar func(prefijo uint32, largo int, dato V) bool)
+}
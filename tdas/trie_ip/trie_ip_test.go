@@ -0,0 +1,151 @@
+package trie_ip_test
+
+import (
+	"math/rand"
+	TDATrieIP "tdas/trie_ip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// ip arma la representacion numerica de una IPv4 a partir de sus cuatro octetos
+func ip(a, b, c, d uint32) uint32 {
+	return a<<24 | b<<16 | c<<8 | d
+}
+
+func TestTrieVacio(t *testing.T) {
+	trie := TDATrieIP.CrearTrieIP[string]()
+	require.Equal(t, 0, trie.Cantidad())
+	require.False(t, trie.Pertenece(ip(10, 0, 0, 0), 8))
+	require.PanicsWithValue(t, "El prefijo no pertenece al trie", func() { trie.Obtener(ip(10, 0, 0, 0), 8) })
+	require.PanicsWithValue(t, "El prefijo no pertenece al trie", func() { trie.Borrar(ip(10, 0, 0, 0), 8) })
+	_, _, _, encontrado := trie.BuscarMasEspecifico(ip(10, 1, 2, 3))
+	require.False(t, encontrado, "Un trie vacio no contiene a ninguna IP")
+}
+
+func TestLargoInvalido(t *testing.T) {
+	trie := TDATrieIP.CrearTrieIP[string]()
+	require.PanicsWithValue(t, "Largo de prefijo invalido", func() { trie.Guardar(0, 33, "x") })
+	require.PanicsWithValue(t, "Largo de prefijo invalido", func() { trie.Guardar(0, -1, "x") })
+	require.PanicsWithValue(t, "Largo de prefijo invalido", func() { trie.Pertenece(0, 40) })
+}
+
+func TestGuardarIgnoraBitsDeHost(t *testing.T) {
+	trie := TDATrieIP.CrearTrieIP[string]()
+	trie.Guardar(ip(192, 168, 1, 77), 24, "casa")
+	require.True(t, trie.Pertenece(ip(192, 168, 1, 0), 24))
+	require.Equal(t, "casa", trie.Obtener(ip(192, 168, 1, 255), 24))
+	trie.Guardar(ip(192, 168, 1, 0), 24, "oficina")
+	require.Equal(t, 1, trie.Cantidad(), "Guardar un prefijo existente deberia actualizar el dato")
+	require.Equal(t, "oficina", trie.Obtener(ip(192, 168, 1, 0), 24))
+}
+
+func TestBuscarMasEspecifico(t *testing.T) {
+	trie := TDATrieIP.CrearTrieIP[string]()
+	trie.Guardar(ip(10, 0, 0, 0), 8, "corporativa")
+	trie.Guardar(ip(10, 20, 0, 0), 16, "sucursal")
+	trie.Guardar(ip(10, 20, 30, 0), 24, "laboratorio")
+	trie.Guardar(ip(10, 20, 30, 40), 32, "servidor")
+
+	casos := []struct {
+		ip       uint32
+		prefijo  uint32
+		largo    int
+		etiqueta string
+	}{
+		{ip(10, 1, 1, 1), ip(10, 0, 0, 0), 8, "corporativa"},
+		{ip(10, 20, 1, 1), ip(10, 20, 0, 0), 16, "sucursal"},
+		{ip(10, 20, 30, 1), ip(10, 20, 30, 0), 24, "laboratorio"},
+		{ip(10, 20, 30, 40), ip(10, 20, 30, 40), 32, "servidor"},
+	}
+	for _, caso := range casos {
+		prefijo, largo, etiqueta, encontrado := trie.BuscarMasEspecifico(caso.ip)
+		require.True(t, encontrado)
+		require.Equal(t, caso.prefijo, prefijo)
+		require.Equal(t, caso.largo, largo)
+		require.Equal(t, caso.etiqueta, etiqueta)
+	}
+	_, _, _, encontrado := trie.BuscarMasEspecifico(ip(11, 0, 0, 1))
+	require.False(t, encontrado, "Ninguna red contiene a 11.0.0.1")
+
+	// La ruta por defecto contiene a todas las IPs
+	trie.Guardar(0, 0, "internet")
+	_, largo, etiqueta, encontrado := trie.BuscarMasEspecifico(ip(11, 0, 0, 1))
+	require.True(t, encontrado)
+	require.Equal(t, 0, largo)
+	require.Equal(t, "internet", etiqueta)
+}
+
+func TestBorrarDejaLosPrefijosMenosEspecificos(t *testing.T) {
+	trie := TDATrieIP.CrearTrieIP[int]()
+	trie.Guardar(ip(172, 16, 0, 0), 12, 1)
+	trie.Guardar(ip(172, 16, 5, 0), 24, 2)
+	require.Equal(t, 2, trie.Borrar(ip(172, 16, 5, 0), 24))
+	require.Equal(t, 1, trie.Cantidad())
+	_, largo, dato, encontrado := trie.BuscarMasEspecifico(ip(172, 16, 5, 9))
+	require.True(t, encontrado)
+	require.Equal(t, 12, largo)
+	require.Equal(t, 1, dato)
+	require.PanicsWithValue(t, "El prefijo no pertenece al trie", func() { trie.Borrar(ip(172, 16, 5, 0), 24) })
+	require.PanicsWithValue(t, "El prefijo no pertenece al trie", func() { trie.Borrar(ip(172, 16, 0, 0), 16) })
+	require.Equal(t, 1, trie.Borrar(ip(172, 16, 0, 0), 12))
+	require.Equal(t, 0, trie.Cantidad())
+}
+
+func TestIterarEnOrden(t *testing.T) {
+	trie := TDATrieIP.CrearTrieIP[string]()
+	trie.Guardar(ip(192, 168, 0, 0), 16, "c")
+	trie.Guardar(ip(10, 0, 0, 0), 8, "a")
+	trie.Guardar(ip(10, 5, 0, 0), 16, "b")
+	trie.Guardar(ip(200, 1, 1, 1), 32, "d")
+
+	var etiquetas []string
+	trie.Iterar(func(prefijo uint32, largo int, dato string) bool {
+		etiquetas = append(etiquetas, dato)
+		return true
+	})
+	require.Equal(t, []string{"a", "b", "c", "d"}, etiquetas)
+
+	etiquetas = nil
+	trie.Iterar(func(prefijo uint32, largo int, dato string) bool {
+		etiquetas = append(etiquetas, dato)
+		return len(etiquetas) < 2
+	})
+	require.Equal(t, []string{"a", "b"}, etiquetas, "La iteracion deberia cortarse al devolver false")
+}
+
+func TestBuscarMasEspecificoContraFuerzaBruta(t *testing.T) {
+	type red struct {
+		prefijo uint32
+		largo   int
+	}
+	generador := rand.New(rand.NewSource(35))
+	trie := TDATrieIP.CrearTrieIP[int]()
+	var redes []red
+	for i := 0; i < 500; i++ {
+		largo := 4 + generador.Intn(29)
+		prefijo := generador.Uint32() &^ (1<<(32-largo) - 1)
+		trie.Guardar(prefijo, largo, len(redes))
+		redes = append(redes, red{prefijo, largo})
+	}
+
+	for i := 0; i < 5000; i++ {
+		consulta := generador.Uint32()
+		if i%2 == 0 {
+			// Buscar IPs dentro de redes conocidas para cubrir tambien los aciertos
+			consulta = redes[generador.Intn(len(redes))].prefijo | generador.Uint32()&0xFF
+		}
+		mejor := -1
+		for j, r := range redes {
+			if consulta&^(1<<(32-r.largo)-1) == r.prefijo && (mejor == -1 || r.largo >= redes[mejor].largo) {
+				mejor = j
+			}
+		}
+		_, largo, dato, encontrado := trie.BuscarMasEspecifico(consulta)
+		require.Equal(t, mejor != -1, encontrado)
+		if encontrado {
+			require.Equal(t, redes[mejor].largo, largo)
+			require.Equal(t, redes[mejor], redes[dato])
+		}
+	}
+}
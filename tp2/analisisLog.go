@@ -2,29 +2,60 @@ package main
 
 import (
 	"bufio"
+	"fmt"
+	"io"
 	"os"
-	"strings"
-	TDADICC "tdas/diccionario"
+	"tp2/interactivo"
 	operacionesComandos "tp2/operComandos"
 )
 
 func main() {
-	hash := TDADICC.CrearHash[string, int]()
-	arbol := TDADICC.CrearABB[uint32, bool](operacionesComandos.CompararIPs)
+	ctx := operacionesComandos.CrearContexto()
+	registro := operacionesComandos.CrearRegistroPredeterminado()
+	in := crearInterprete(registro, ctx)
 
-	scanner := bufio.NewScanner(os.Stdin)
-	for scanner.Scan() {
-		line := scanner.Text()
-		partes := strings.Fields(line)
-		comando := partes[0]
-		parametro1 := ""
-		parametro2 := ""
-		if len(partes) > 1 {
-			parametro1 = partes[1]
+	if len(os.Args) > 1 {
+		if !ejecutarSubcomando(registro, ctx, os.Args[1:]) {
+			os.Exit(1)
 		}
-		if len(partes) > 2 {
-			parametro2 = partes[2]
+		return
+	}
+	if interactivo.EsTerminal(os.Stdin) {
+		interactivo.Ejecutar(registro, ctx, in.procesarLinea)
+		return
+	}
+	// Si algun comando falla, el programa termina con estado 1 luego de procesar toda la entrada
+	in.procesar(os.Stdin, "")
+	if !in.exito {
+		os.Exit(1)
+	}
+}
+
+// PRE: el interprete debe de existir. origen es la ruta del script que se lee, o "" si es la entrada estandar
+// POST: ejecuta los comandos de la entrada, uno por linea, escribiendo en stderr los errores. Los errores de un script
+// se prefijan con su ruta y el numero de linea. Si falla algun comando marca al interprete como fallido
+func (in *interprete) procesar(entrada io.Reader, origen string) {
+	scanner := bufio.NewScanner(entrada)
+	for numero := 1; scanner.Scan(); numero++ {
+		err := in.procesarLinea(scanner.Text())
+		if err == nil {
+			continue
 		}
-		operacionesComandos.ProcesarEntrada(comando, parametro1, parametro2, hash, arbol)
+		in.exito = false
+		if origen == "" {
+			fmt.Fprintln(os.Stderr, err)
+		} else {
+			fmt.Fprintf(os.Stderr, "%s:%d: %v\n", origen, numero, err)
+		}
+	}
+}
+
+// PRE: el registro y el contexto deben de existir y partes debe de tener el nombre del comando seguido de sus parametros
+// POST: ejecuta el comando, escribiendo en stderr su error si fallo. Retorna false si fallo
+func ejecutarComando(registro *operacionesComandos.Registro, ctx *operacionesComandos.Contexto, partes []string) bool {
+	if err := registro.Ejecutar(ctx, partes[0], partes[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return false
 	}
+	return true
 }
@@ -1,6 +1,6 @@
 module tp2
 
-go 1.22.0
+go 1.23.0
 
 replace tdas => ../tdas
 
@@ -0,0 +1,124 @@
+package interactivo
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	operacionesComandos "tp2/operComandos"
+)
+
+const (
+	COMANDO_AYUDA         = "ayuda"
+	SEPARADOR_DIRECTORIO  = string(filepath.Separator)
+	MAX_DISTANCIA_SUGERIR = 2
+)
+
+// PRE: cursor debe de ser una posicion de la linea
+// POST: retorna la posicion donde empieza la palabra que termina en el cursor y los candidatos para completarla,
+// ordenados. La primera palabra, y el parametro de ayuda, se completan con nombres de comandos, y el resto con rutas
+func candidatos(registro *operacionesComandos.Registro, linea []rune, cursor int) (int, []string) {
+	inicio := cursor
+	for inicio > 0 && linea[inicio-1] != ' ' {
+		inicio--
+	}
+	palabra := string(linea[inicio:cursor])
+	anteriores := strings.Fields(string(linea[:inicio]))
+	if len(anteriores) == 0 || (len(anteriores) == 1 && anteriores[0] == COMANDO_AYUDA) {
+		return inicio, candidatosComando(registro, palabra)
+	}
+	return inicio, candidatosRuta(palabra)
+}
+
+// PRE: el registro debe de existir
+// POST: retorna los nombres de los comandos que empiezan con el prefijo
+func candidatosComando(registro *operacionesComandos.Registro, prefijo string) []string {
+	var nombres []string
+	for comando := range registro.Comandos() {
+		if strings.HasPrefix(comando.Nombre, prefijo) {
+			nombres = append(nombres, comando.Nombre)
+		}
+	}
+	slices.Sort(nombres)
+	return nombres
+}
+
+// PRE:
+// POST: retorna las rutas de archivos y directorios que empiezan con el prefijo. Los directorios terminan con el
+// separador, para seguir completando dentro de ellos. Los archivos ocultos solo se incluyen si el prefijo los pide
+func candidatosRuta(prefijo string) []string {
+	directorio, base := filepath.Split(prefijo)
+	leer := directorio
+	if leer == "" {
+		leer = "."
+	}
+	entradas, err := os.ReadDir(leer)
+	if err != nil {
+		return nil
+	}
+	var rutas []string
+	for _, entrada := range entradas {
+		nombre := entrada.Name()
+		if !strings.HasPrefix(nombre, base) || (strings.HasPrefix(nombre, ".") && !strings.HasPrefix(base, ".")) {
+			continue
+		}
+		ruta := directorio + nombre
+		if entrada.IsDir() {
+			ruta += SEPARADOR_DIRECTORIO
+		}
+		rutas = append(rutas, ruta)
+	}
+	slices.Sort(rutas)
+	return rutas
+}
+
+// PRE: candidatos no debe de ser vacio
+// POST: retorna el prefijo mas largo que comparten todos los candidatos
+func prefijoComun(candidatos []string) string {
+	comun := candidatos[0]
+	for _, candidato := range candidatos[1:] {
+		largo := 0
+		for largo < len(comun) && largo < len(candidato) && comun[largo] == candidato[largo] {
+			largo++
+		}
+		comun = comun[:largo]
+	}
+	return comun
+}
+
+// PRE: el registro debe de existir
+// POST: retorna el comando registrado mas parecido al nombre y true, si esta a lo sumo a MAX_DISTANCIA_SUGERIR
+// ediciones de distancia. Si no hay ninguno tan parecido retorna false
+func sugerirComando(registro *operacionesComandos.Registro, nombre string) (string, bool) {
+	sugerencia, mejor := "", MAX_DISTANCIA_SUGERIR+1
+	for comando := range registro.Comandos() {
+		if distancia := distanciaEdicion(nombre, comando.Nombre); distancia < mejor {
+			sugerencia, mejor = comando.Nombre, distancia
+		}
+	}
+	return sugerencia, mejor <= MAX_DISTANCIA_SUGERIR
+}
+
+// PRE:
+// POST: retorna la distancia de Levenshtein entre las dos palabras: la minima cantidad de inserciones, borrados y
+// reemplazos de caracteres para transformar una en la otra
+func distanciaEdicion(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	anterior := make([]int, len(rb)+1)
+	actual := make([]int, len(rb)+1)
+	for j := range anterior {
+		anterior[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		actual[0] = i
+		for j := 1; j <= len(rb); j++ {
+			costo := 1
+			if ra[i-1] == rb[j-1] {
+				costo = 0
+			}
+			actual[j] = min(anterior[j]+1, actual[j-1]+1, anterior[j-1]+costo)
+		}
+		anterior, actual = actual, anterior
+	}
+	return anterior[len(rb)]
+}
@@ -0,0 +1,203 @@
+package interactivo
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	operacionesComandos "tp2/operComandos"
+	"unicode"
+)
+
+const (
+	CTRL_A    = 0x01
+	CTRL_C    = 0x03
+	CTRL_D    = 0x04
+	CTRL_E    = 0x05
+	CTRL_H    = 0x08
+	TAB       = 0x09
+	CTRL_L    = 0x0c
+	ENTER     = 0x0d
+	CTRL_U    = 0x15
+	ESCAPE    = 0x1b
+	RETROCESO = 0x7f
+
+	CAMPANA          = "\a"
+	BORRAR_HASTA_FIN = "\x1b[K"
+	LIMPIAR_PANTALLA = "\x1b[H\x1b[2J"
+)
+
+// Editor de una linea sobre una terminal en modo crudo: permite mover el cursor, recorrer el historial y completar
+// con Tab los nombres de comandos y las rutas de archivos
+type editor struct {
+	entrada   *bufio.Reader
+	salida    io.Writer
+	prompt    string
+	historial *historial
+	registro  *operacionesComandos.Registro
+}
+
+// Estado de la linea que se esta editando
+type lineaEditada struct {
+	runas  []rune
+	cursor int
+}
+
+// PRE: la terminal debe de estar en modo crudo
+// POST: lee una linea editandola hasta que se presione Enter. Si se presiona Ctrl-D con la linea vacia, o se termina
+// la entrada, retorna io.EOF. Ctrl-C descarta la linea actual y empieza una nueva
+func (e *editor) leerLinea() (string, error) {
+	linea := &lineaEditada{}
+	posicionHistorial := e.historial.cantidad()
+	// Linea que se estaba escribiendo antes de recorrer el historial, para volver a ella
+	pendiente := ""
+	e.redibujar(linea)
+	for {
+		r, _, err := e.entrada.ReadRune()
+		if err != nil {
+			return "", err
+		}
+		switch r {
+		case ENTER, '\n':
+			fmt.Fprint(e.salida, "\r\n")
+			return string(linea.runas), nil
+		case CTRL_C:
+			fmt.Fprint(e.salida, "^C\r\n")
+			linea = &lineaEditada{}
+			posicionHistorial = e.historial.cantidad()
+		case CTRL_D:
+			if len(linea.runas) == 0 {
+				fmt.Fprint(e.salida, "\r\n")
+				return "", io.EOF
+			}
+			linea.borrar(linea.cursor)
+		case RETROCESO, CTRL_H:
+			if linea.cursor > 0 {
+				linea.cursor--
+				linea.borrar(linea.cursor)
+			}
+		case CTRL_A:
+			linea.cursor = 0
+		case CTRL_E:
+			linea.cursor = len(linea.runas)
+		case CTRL_U:
+			linea.runas = linea.runas[linea.cursor:]
+			linea.cursor = 0
+		case CTRL_L:
+			fmt.Fprint(e.salida, LIMPIAR_PANTALLA)
+		case TAB:
+			e.completar(linea)
+		case ESCAPE:
+			switch e.leerSecuenciaEscape() {
+			case 'A':
+				if posicionHistorial > 0 {
+					if posicionHistorial == e.historial.cantidad() {
+						pendiente = string(linea.runas)
+					}
+					posicionHistorial--
+					linea.reemplazar(e.historial.linea(posicionHistorial))
+				}
+			case 'B':
+				if posicionHistorial < e.historial.cantidad() {
+					posicionHistorial++
+					if posicionHistorial == e.historial.cantidad() {
+						linea.reemplazar(pendiente)
+					} else {
+						linea.reemplazar(e.historial.linea(posicionHistorial))
+					}
+				}
+			case 'C':
+				linea.cursor = min(linea.cursor+1, len(linea.runas))
+			case 'D':
+				linea.cursor = max(linea.cursor-1, 0)
+			case 'H':
+				linea.cursor = 0
+			case 'F':
+				linea.cursor = len(linea.runas)
+			case '~':
+				linea.borrar(linea.cursor)
+			}
+		default:
+			if unicode.IsPrint(r) {
+				linea.insertar(string(r))
+			}
+		}
+		e.redibujar(linea)
+	}
+}
+
+// PRE: se debe de haber leido el caracter de escape
+// POST: lee el resto de la secuencia de escape de una tecla especial y retorna su caracter final: 'A' a 'D' para las
+// flechas, 'H' y 'F' para Inicio y Fin, y '~' para Suprimir. Retorna 0 si la secuencia no es una de esas
+func (e *editor) leerSecuenciaEscape() rune {
+	introductor, _, err := e.entrada.ReadRune()
+	if err != nil || (introductor != '[' && introductor != 'O') {
+		return 0
+	}
+	final, _, err := e.entrada.ReadRune()
+	if err != nil {
+		return 0
+	}
+	if final != '3' {
+		return final
+	}
+	// Suprimir se envia como "ESC [ 3 ~"
+	if siguiente, _, err := e.entrada.ReadRune(); err == nil && siguiente == '~' {
+		return '~'
+	}
+	return 0
+}
+
+// PRE: la linea debe de existir
+// POST: completa la palabra que termina en el cursor. Si hay un unico candidato la completa entera, y si hay varios la
+// completa hasta su prefijo comun. Si no se puede avanzar, muestra los candidatos debajo de la linea
+func (e *editor) completar(linea *lineaEditada) {
+	inicio, opciones := candidatos(e.registro, linea.runas, linea.cursor)
+	if len(opciones) == 0 {
+		fmt.Fprint(e.salida, CAMPANA)
+		return
+	}
+	palabra := string(linea.runas[inicio:linea.cursor])
+	completado := prefijoComun(opciones)
+	if len(opciones) == 1 && !strings.HasSuffix(completado, SEPARADOR_DIRECTORIO) {
+		completado += " "
+	}
+	if completado != palabra {
+		linea.insertar(strings.TrimPrefix(completado, palabra))
+		return
+	}
+	fmt.Fprintf(e.salida, "\r\n%s\r\n", strings.Join(opciones, "  "))
+}
+
+// PRE: la linea debe de existir
+// POST: vuelve a escribir el prompt y la linea, dejando el cursor de la terminal en la posicion del cursor de la linea
+func (e *editor) redibujar(linea *lineaEditada) {
+	fmt.Fprintf(e.salida, "\r%s%s%s", e.prompt, string(linea.runas), BORRAR_HASTA_FIN)
+	if atras := len(linea.runas) - linea.cursor; atras > 0 {
+		fmt.Fprintf(e.salida, "\x1b[%dD", atras)
+	}
+}
+
+// PRE:
+// POST: inserta el texto en la posicion del cursor y avanza el cursor hasta el final de lo insertado
+func (l *lineaEditada) insertar(texto string) {
+	nuevas := []rune(texto)
+	resto := append(nuevas, l.runas[l.cursor:]...)
+	l.runas = append(l.runas[:l.cursor], resto...)
+	l.cursor += len(nuevas)
+}
+
+// PRE:
+// POST: borra el caracter de la posicion indicada, si existe
+func (l *lineaEditada) borrar(posicion int) {
+	if posicion < len(l.runas) {
+		l.runas = append(l.runas[:posicion], l.runas[posicion+1:]...)
+	}
+}
+
+// PRE:
+// POST: reemplaza el contenido de la linea por el texto, con el cursor al final
+func (l *lineaEditada) reemplazar(texto string) {
+	l.runas = []rune(texto)
+	l.cursor = len(l.runas)
+}
@@ -0,0 +1,92 @@
+package interactivo
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+const (
+	ARCHIVO_HISTORIAL = ".analisisLog_historial"
+	HISTORIAL_MAXIMO  = 1000
+)
+
+// Historial de las lineas ingresadas, que se guarda en un archivo para las proximas sesiones
+type historial struct {
+	lineas  []string
+	archivo string
+}
+
+// PRE:
+// POST: carga el historial guardado en el directorio personal del usuario, quedandose con las ultimas HISTORIAL_MAXIMO
+// lineas. Si no se puede determinar el directorio personal el historial solo dura la sesion
+func cargarHistorial() *historial {
+	h := &historial{}
+	directorio, err := os.UserHomeDir()
+	if err != nil {
+		return h
+	}
+	h.archivo = filepath.Join(directorio, ARCHIVO_HISTORIAL)
+	file, err := os.Open(h.archivo)
+	if err != nil {
+		return h
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		h.lineas = append(h.lineas, scanner.Text())
+	}
+	if len(h.lineas) > HISTORIAL_MAXIMO {
+		h.lineas = h.lineas[len(h.lineas)-HISTORIAL_MAXIMO:]
+		h.reescribir()
+	}
+	return h
+}
+
+// PRE: el historial debe de existir
+// POST: retorna la cantidad de lineas del historial
+func (h *historial) cantidad() int {
+	return len(h.lineas)
+}
+
+// PRE: el historial debe de existir y 0 <= posicion < cantidad
+// POST: retorna la linea de la posicion indicada, siendo 0 la mas antigua
+func (h *historial) linea(posicion int) string {
+	return h.lineas[posicion]
+}
+
+// PRE: el historial debe de existir
+// POST: agrega la linea, sin los espacios finales, al historial y al archivo, salvo que este vacia o sea igual a la ultima
+func (h *historial) agregar(linea string) {
+	linea = strings.TrimRightFunc(linea, unicode.IsSpace)
+	if strings.TrimSpace(linea) == "" || (len(h.lineas) > 0 && h.lineas[len(h.lineas)-1] == linea) {
+		return
+	}
+	h.lineas = append(h.lineas, linea)
+	if h.archivo == "" {
+		return
+	}
+	file, err := os.OpenFile(h.archivo, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	file.WriteString(linea + "\n")
+}
+
+// PRE: el historial debe de existir con un archivo
+// POST: reemplaza el contenido del archivo por las lineas del historial
+func (h *historial) reescribir() {
+	file, err := os.Create(h.archivo)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	escritor := bufio.NewWriter(file)
+	for _, linea := range h.lineas {
+		escritor.WriteString(linea + "\n")
+	}
+	escritor.Flush()
+}
@@ -0,0 +1,93 @@
+package interactivo
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+	operacionesComandos "tp2/operComandos"
+)
+
+const (
+	PROMPT         = "analisisLog> "
+	PROMPT_DATASET = "analisisLog[%s]> "
+	COMANDO_SALIR  = "salir"
+)
+
+// Procesa una linea ingresada como lo hace la entrada por lotes: comentarios, variables y comandos
+type ProcesadorLineas func(linea string) error
+
+// PRE: el registro, el contexto y el procesador deben de existir, y la entrada estandar debe de ser una terminal
+// POST: ejecuta con el procesador las lineas que se ingresan de forma interactiva hasta que se ingrese salir o se
+// presione Ctrl-D con la linea vacia. Despues de cada comando muestra cuanto tardo, y si el comando no existe sugiere
+// el mas parecido
+func Ejecutar(registro *operacionesComandos.Registro, ctx *operacionesComandos.Contexto, procesar ProcesadorLineas) {
+	e := &editor{
+		entrada:   bufio.NewReader(os.Stdin),
+		salida:    os.Stdout,
+		historial: cargarHistorial(),
+		registro:  registro,
+	}
+	fmt.Printf("Ingrese %s para ver los comandos disponibles y %s o Ctrl-D para terminar\n", COMANDO_AYUDA, COMANDO_SALIR)
+	for {
+		e.prompt = prompt(ctx)
+		linea, err := leerLineaCruda(e)
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				fmt.Fprintln(os.Stderr, err)
+			}
+			return
+		}
+		partes := strings.Fields(linea)
+		if len(partes) == 0 {
+			continue
+		}
+		e.historial.agregar(linea)
+		if partes[0] == COMANDO_SALIR {
+			return
+		}
+		ejecutarMedido(registro, procesar, linea)
+	}
+}
+
+// PRE: el contexto debe de existir
+// POST: retorna el prompt, que incluye el nombre del dataset actual si no es el inicial
+func prompt(ctx *operacionesComandos.Contexto) string {
+	if ctx.Nombre == operacionesComandos.DATASET_INICIAL {
+		return PROMPT
+	}
+	return fmt.Sprintf(PROMPT_DATASET, ctx.Nombre)
+}
+
+// PRE: la entrada estandar debe de ser una terminal
+// POST: lee una linea con el editor, con la terminal en modo crudo solo mientras se edita, para que los comandos
+// escriban su salida de forma normal
+func leerLineaCruda(e *editor) (string, error) {
+	restaurar, err := activarModoCrudo(os.Stdin.Fd())
+	if err != nil {
+		return "", err
+	}
+	defer restaurar()
+	return e.leerLinea()
+}
+
+// PRE: el registro y el procesador deben de existir
+// POST: procesa la linea y muestra su error, si fallo, y cuanto tardo
+func ejecutarMedido(registro *operacionesComandos.Registro, procesar ProcesadorLineas, linea string) {
+	inicio := time.Now()
+	err := procesar(linea)
+	duracion := time.Since(inicio)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		var errComando *operacionesComandos.ErrorComando
+		if errors.Is(err, operacionesComandos.ErrComandoDesconocido) && errors.As(err, &errComando) {
+			if sugerencia, ok := sugerirComando(registro, errComando.Comando); ok {
+				fmt.Fprintf(os.Stderr, "¿Quiso decir %s?\n", sugerencia)
+			}
+		}
+	}
+	fmt.Printf("Tardó: %s\n", duracion.Round(time.Microsecond))
+}
@@ -0,0 +1,8 @@
+package interactivo
+
+import "syscall"
+
+const (
+	IOCTL_LEER_ATRIBUTOS  = syscall.TIOCGETA
+	IOCTL_FIJAR_ATRIBUTOS = syscall.TIOCSETA
+)
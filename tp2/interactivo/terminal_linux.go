@@ -0,0 +1,8 @@
+package interactivo
+
+import "syscall"
+
+const (
+	IOCTL_LEER_ATRIBUTOS  = syscall.TCGETS
+	IOCTL_FIJAR_ATRIBUTOS = syscall.TCSETS
+)
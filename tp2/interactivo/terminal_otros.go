@@ -0,0 +1,20 @@
+//go:build !linux && !darwin
+
+package interactivo
+
+import (
+	"errors"
+	"os"
+)
+
+// PRE: file debe de estar abierto
+// POST: en este sistema no se soporta el modo interactivo, asi que la entrada siempre se procesa como un lote
+func EsTerminal(file *os.File) bool {
+	return false
+}
+
+// PRE:
+// POST: retorna un error, ya que en este sistema no se soporta el modo crudo de la terminal
+func activarModoCrudo(fd uintptr) (func(), error) {
+	return nil, errors.New("modo interactivo no soportado en este sistema")
+}
@@ -0,0 +1,54 @@
+//go:build linux || darwin
+
+package interactivo
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// PRE: file debe de estar abierto
+// POST: retorna true si el archivo es una terminal
+func EsTerminal(file *os.File) bool {
+	_, err := leerAtributos(file.Fd())
+	return err == nil
+}
+
+// PRE: fd debe de ser el descriptor de una terminal
+// POST: pone la terminal en modo crudo, en el que cada tecla se lee apenas se presiona, sin eco y sin que Ctrl-C
+// envie una señal. Retorna la funcion que restaura el modo anterior
+func activarModoCrudo(fd uintptr) (func(), error) {
+	original, err := leerAtributos(fd)
+	if err != nil {
+		return nil, err
+	}
+	crudo := original
+	crudo.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP | syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	crudo.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	crudo.Cflag &^= syscall.CSIZE | syscall.PARENB
+	crudo.Cflag |= syscall.CS8
+	crudo.Cc[syscall.VMIN] = 1
+	crudo.Cc[syscall.VTIME] = 0
+	if err := fijarAtributos(fd, &crudo); err != nil {
+		return nil, err
+	}
+	return func() { fijarAtributos(fd, &original) }, nil
+}
+
+func leerAtributos(fd uintptr) (syscall.Termios, error) {
+	var atributos syscall.Termios
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, IOCTL_LEER_ATRIBUTOS, uintptr(unsafe.Pointer(&atributos)))
+	if errno != 0 {
+		return atributos, errno
+	}
+	return atributos, nil
+}
+
+func fijarAtributos(fd uintptr, atributos *syscall.Termios) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, IOCTL_FIJAR_ATRIBUTOS, uintptr(unsafe.Pointer(atributos)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -2,105 +2,528 @@ package operComandos
 
 import (
 	"fmt"
+	"io"
+	"iter"
 	"os"
 	"strconv"
+	"strings"
+	TDAINTERVALOS "tdas/arbol_intervalos"
+	TDABLOOM "tdas/bloom"
 	TDAHEAP "tdas/cola_prioridad"
 	TDADICC "tdas/diccionario"
+	TDAORD "tdas/ordenamiento"
+	TDATOPK "tdas/top_k"
+	TDATRIEIP "tdas/trie_ip"
+	"time"
 )
 
 // PRE: 'archivo' debe de ser una ruta valida a un archivo que se pueda abrir en modo lectura
-// POST: devuelve un puntero al archivo abierto exitosamente. Si ocurre un error al intentar abrir el archivo escribe un mensaje de error en stderr, indicando el comando relacionado y devuelve nil
-func abrirArchivo(archivo string, comando string) *os.File {
+// POST: devuelve un puntero al archivo abierto exitosamente. Si ocurre un error al intentar abrir el archivo devuelve nil y el error tipado correspondiente
+func AbrirArchivo(archivo string) (*os.File, error) {
 	file, err := os.Open(archivo)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error en comando %s\n", comando)
-		return nil
+		return nil, errorAbrirArchivo(archivo, err)
 	}
-	return file
+	return file, nil
 }
 
-// PRE: el arbol y el hash deben de estar vacios, el archivo debe de estar abierto en modo lectura y se debe de ingresar un comando existente
-// POST: ejecuta el comando correspondiente, realizando la tarea del ejecutado. Si ocurre un error en algun caso, escribe el mensaje correspondiente en stderr y termina la ejecucion del comando.
-func ProcesarEntrada(comando, parametro1, parametro2 string, hash TDADICC.Diccionario[string, int], arbol TDADICC.DiccionarioOrdenado[uint32, bool]) {
-	switch comando {
-	case "agregar_archivo":
-		file := abrirArchivo(parametro1, comando)
-		if file == nil {
-			return
-		}
-		defer file.Close()
-		actualizarIpsYRecursos(arbol, hash, file)
-		sospechososDoS(file)
+// PRE: parametros debe de contener los parametros del comando en orden
+// POST: retorna el parametro de la posicion indicada, o "" si no se ingreso
+func parametro(parametros []string, posicion int) string {
+	if posicion < len(parametros) {
+		return parametros[posicion]
+	}
+	return ""
+}
+
+// PRE: el registro debe de existir
+// POST: registra en el registro todos los comandos de analisis de logs
+func RegistrarComandosAnalisis(registro *Registro) {
+	registro.Registrar(Comando{
+		Nombre:        "agregar_archivo",
+		Parametros:    "<file> [estricto]",
+		Descripcion:   "Carga un archivo de log y muestra las IPs sospechosas de DoS. En modo estricto no lo carga si tiene lineas invalidas",
+		MinParametros: 1,
+		MaxParametros: 2,
+		Ejecutar:      ejecutarAgregarArchivo,
+	})
+	registro.Registrar(Comando{
+		Nombre:        "agregar_archivos_fusionados",
+		Parametros:    "<file1> [file2 ...] [estricto]",
+		Descripcion:   "Carga varios archivos de log fusionandolos en orden cronologico, detectando los DoS repartidos entre ellos. En modo estricto no carga ninguno si alguno tiene lineas invalidas",
+		MinParametros: 1,
+		MaxParametros: PARAMETROS_ILIMITADOS,
+		Ejecutar:      ejecutarAgregarArchivosFusionados,
+	})
+	registro.Registrar(Comando{
+		Nombre:        "ver_visitantes",
+		Parametros:    "<IP1> <IP2>",
+		Descripcion:   "Lista en orden las IPs que realizaron alguna peticion dentro del rango, con los limites inclusive",
+		MinParametros: 2,
+		MaxParametros: 2,
+		RequiereDatos: true,
+		Ejecutar:      ejecutarVerVisitantes,
+	})
+	registro.Registrar(Comando{
+		Nombre:        "ver_mas_visitados",
+		Parametros:    "<n> [aprox]",
+		Descripcion:   "Muestra los n recursos mas solicitados, o su estimacion con memoria acotada en modo aprox o en un dataset aproximado",
+		MinParametros: 1,
+		MaxParametros: 2,
+		RequiereDatos: true,
+		Ejecutar:      ejecutarVerMasVisitados,
+	})
+	registro.Registrar(Comando{
+		Nombre:                 "ver_mas_visitados_prefijo",
+		Parametros:             "<prefijo> <n>",
+		Descripcion:            "Muestra los n recursos mas solicitados cuya ruta esta bajo el prefijo",
+		MinParametros:          2,
+		MaxParametros:          2,
+		RequiereDatos:          true,
+		RequiereConteosExactos: true,
+		Ejecutar:               ejecutarVerMasVisitadosPrefijo,
+	})
+	registro.Registrar(Comando{
+		Nombre:                 "ver_arbol_recursos",
+		Parametros:             "<profundidad>",
+		Descripcion:            "Muestra las rutas de los recursos hasta la profundidad indicada, con la suma de visitas bajo cada una",
+		MinParametros:          1,
+		MaxParametros:          1,
+		RequiereDatos:          true,
+		RequiereConteosExactos: true,
+		Ejecutar:               ejecutarVerArbolRecursos,
+	})
+	registro.Registrar(Comando{
+		Nombre:        "contar_visitantes_aprox",
+		Parametros:    "[recurso]",
+		Descripcion:   "Estima la cantidad de IPs distintas de todos los logs, o de las que solicitaron el recurso",
+		MinParametros: 0,
+		MaxParametros: 1,
+		RequiereDatos: true,
+		Ejecutar:      ejecutarContarVisitantesAprox,
+	})
+	registro.Registrar(Comando{
+		Nombre:        "ver_incidentes",
+		Parametros:    "<desde> [hasta]",
+		Descripcion:   "Muestra los incidentes de DoS que se solapan con el instante o el rango de tiempo",
+		MinParametros: 1,
+		MaxParametros: 2,
+		RequiereDatos: true,
+		Ejecutar:      ejecutarVerIncidentes,
+	})
+	registro.Registrar(Comando{
+		Nombre:        "ya_visto",
+		Parametros:    "<IP>",
+		Descripcion:   "Responde si la IP realizo alguna peticion en los logs o los filtros cargados, usando filtros de Bloom",
+		MinParametros: 1,
+		MaxParametros: 1,
+		Ejecutar:      ejecutarYaVisto,
+	})
+	registro.Registrar(Comando{
+		Nombre:        "guardar_filtro_vistos",
+		Parametros:    "<file>",
+		Descripcion:   "Guarda en el archivo un filtro de Bloom con las IPs del dataset actual, para consultarlo luego con ya_visto",
+		MinParametros: 1,
+		MaxParametros: 1,
+		RequiereDatos: true,
+		Ejecutar:      ejecutarGuardarFiltroVistos,
+	})
+	registro.Registrar(Comando{
+		Nombre:        "cargar_filtro_vistos",
+		Parametros:    "<file>",
+		Descripcion:   "Agrega al dataset actual un filtro guardado, como una carga mas que ya_visto consulta y que se puede quitar",
+		MinParametros: 1,
+		MaxParametros: 1,
+		Ejecutar:      ejecutarCargarFiltroVistos,
+	})
+	registro.Registrar(Comando{
+		Nombre:        "cargar_redes",
+		Parametros:    "<file>",
+		Descripcion:   "Carga un archivo de redes CIDR con sus etiquetas, que se muestran junto a las IPs",
+		MinParametros: 1,
+		MaxParametros: 1,
+		Ejecutar:      ejecutarCargarRedes,
+	})
+	registro.Registrar(Comando{
+		Nombre:        "quitar_archivo",
+		Parametros:    "<file>",
+		Descripcion:   "Quita lo que aporto el archivo a todos los analisis. Si se cargo fusionado con otros, los quita a todos",
+		MinParametros: 1,
+		MaxParametros: 1,
+		Ejecutar:      ejecutarQuitarArchivo,
+	})
+	registro.Registrar(Comando{
+		Nombre:        "limpiar",
+		Descripcion:   "Descarta todos los logs cargados en el dataset actual. Las redes cargadas se mantienen",
+		MinParametros: 0,
+		MaxParametros: 0,
+		Ejecutar:      ejecutarLimpiar,
+	})
+	registrarComandosVisitantes(registro)
+	registrarComandosDatasets(registro)
+	registrarComandosComparacion(registro)
+}
+
+// PRE: el contexto debe de existir
+// POST: carga el archivo de log en el contexto, reportando sus lineas invalidas. En modo estricto no lo carga si tiene alguna
+func ejecutarAgregarArchivo(ctx *Contexto, parametros []string) error {
+	archivo, modo := parametros[0], parametro(parametros, 1)
+	if err := errorAgregarArchivo(modo); err != nil {
+		return err
+	}
+	file, err := AbrirArchivo(archivo)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	var errLectura error
+	reporte := validarLineas(lineasArchivo(file, &errLectura), archivo)
+	if errLectura != nil {
+		return errLectura
+	}
+	imprimirLineasInvalidas(ctx.Salida, reporte)
+	if modo == MODO_ESTRICTO && reporte.invalidas > 0 {
+		return errorDetallado(ErrArchivoInvalido, "%s tiene %d lineas invalidas", archivo, reporte.invalidas)
+	}
+	ctx.analizarLineas([]string{archivo}, filtrarValidas(lineasArchivo(file, &errLectura)))
+	return errLectura
+}
 
-	case "ver_visitantes":
-		if errorVerVisitantes(parametro2, comando) {
-			return
+// PRE: el contexto debe de existir
+// POST: carga los archivos de log en el contexto fusionados en orden cronologico, reportando sus lineas invalidas. Si
+// el ultimo parametro es el modo estricto, no carga ninguno si alguno tiene lineas invalidas
+func ejecutarAgregarArchivosFusionados(ctx *Contexto, parametros []string) error {
+	archivos := parametros
+	estricto := len(parametros) > 1 && parametros[len(parametros)-1] == MODO_ESTRICTO
+	if estricto {
+		archivos = parametros[:len(parametros)-1]
+	}
+	files, err := abrirArchivos(archivos)
+	if err != nil {
+		return err
+	}
+	defer cerrarArchivos(files)
+	var conInvalidas []string
+	var errLectura error
+	for i, file := range files {
+		reporte := validarLineas(lineasArchivo(file, &errLectura), archivos[i])
+		if errLectura != nil {
+			return errLectura
 		}
-		verVisitantes(arbol, parametro1, parametro2)
-	case "ver_mas_visitados":
-		n, err := strconv.Atoi(parametro1)
-		if errorVerMasVisitados(err, comando) {
-			return
+		imprimirLineasInvalidas(ctx.Salida, reporte)
+		if reporte.invalidas > 0 {
+			conInvalidas = append(conInvalidas, fmt.Sprintf("%s tiene %d lineas invalidas", archivos[i], reporte.invalidas))
 		}
-		verMasVisitados(n, hash)
-	default:
-		fmt.Println("Comando no reconocido")
 	}
+	if estricto && len(conInvalidas) > 0 {
+		return errorDetallado(ErrArchivoInvalido, "%s", strings.Join(conInvalidas, ", "))
+	}
+	ctx.analizarLineas(archivos, lineasFusionadas(files, &errLectura))
+	return errLectura
 }
 
-// PRE: el arbol debe de existir, con las IPs inicializadas y ordenadas
-// POST: itera el ABB y muestra las IPs dentro del rango especificado por parametro
-func verVisitantes(arbol TDADICC.DiccionarioOrdenado[uint32, bool], desdeStr, hastaStr string) {
-	desde := ipStringANumero(desdeStr)
-	hasta := ipStringANumero(hastaStr)
-	fmt.Println("Visitantes:")
-	arbol.IterarRango(&desde, &hasta, func(clave uint32, dato bool) bool {
-		fmt.Printf("\t%s\n", ipAString(clave))
-		return true
-	})
-	fmt.Println("OK")
+// PRE: el contexto debe de existir
+// POST: muestra las IPs visitantes dentro del rango indicado
+func ejecutarVerVisitantes(ctx *Contexto, parametros []string) error {
+	desde, hasta, err := parsearRangoIPs(parametros[0], parametros[1])
+	if err != nil {
+		return err
+	}
+	verVisitantes(ctx.Salida, ctx.Arbol, ctx.Redes, desde, hasta)
+	return nil
 }
 
-// PRE: se debe de pasar como parametro un comando valido
-// POST: Devuelve `true` si `parametro2` esta vacio y escribe un mensaje de error en stderr. Devuelve `false` en caso contrario.
-func errorVerVisitantes(parametro2, comando string) bool {
-	if parametro2 == "" {
-		fmt.Fprintf(os.Stderr, "Error en comando %s\n", comando)
-		return true
+// PRE: el contexto debe de existir
+// POST: muestra los n recursos mas visitados, exactos o aproximados segun el modo. Un dataset aproximado siempre
+// responde con la estimacion, ya que no tiene los conteos exactos
+func ejecutarVerMasVisitados(ctx *Contexto, parametros []string) error {
+	nStr, modo := parametros[0], parametro(parametros, 1)
+	n, err := strconv.Atoi(nStr)
+	if err := errorVerMasVisitados(err, nStr, modo); err != nil {
+		return err
+	}
+	if modo == MODO_APROXIMADO || ctx.Aproximado {
+		verMasVisitadosAprox(ctx.Salida, n, ctx.TopK)
+		return nil
+	}
+	verMasVisitados(ctx.Salida, n, ctx.Recursos.Todos(), "")
+	return nil
+}
+
+// PRE: el contexto debe de existir
+// POST: muestra los n recursos mas visitados bajo el prefijo
+func ejecutarVerMasVisitadosPrefijo(ctx *Contexto, parametros []string) error {
+	prefijo, nStr := parametros[0], parametros[1]
+	n, err := strconv.Atoi(nStr)
+	if err := errorCantidad(err, nStr); err != nil {
+		return err
+	}
+	verMasVisitados(ctx.Salida, n, ctx.Recursos.TodosPrefijo(normalizarPrefijo(prefijo)), prefijo)
+	return nil
+}
+
+// PRE: el contexto debe de existir
+// POST: muestra el arbol de recursos hasta la profundidad indicada
+func ejecutarVerArbolRecursos(ctx *Contexto, parametros []string) error {
+	profundidad, err := strconv.Atoi(parametros[0])
+	if err := errorVerArbolRecursos(err, profundidad, parametros[0]); err != nil {
+		return err
+	}
+	verArbolRecursos(ctx.Salida, ctx.Recursos, profundidad)
+	return nil
+}
+
+// PRE: el contexto debe de existir
+// POST: muestra la cantidad aproximada de visitantes unicos, en total o del recurso indicado
+func ejecutarContarVisitantesAprox(ctx *Contexto, parametros []string) error {
+	contarVisitantesAprox(ctx.Salida, ctx.VisitantesAprox, parametro(parametros, 0))
+	return nil
+}
+
+// PRE: el contexto debe de existir
+// POST: muestra los incidentes de DoS que se solapan con el instante o el rango indicado
+func ejecutarVerIncidentes(ctx *Contexto, parametros []string) error {
+	desde, hasta, err := parsearRangoTiempo(parametros[0], parametro(parametros, 1))
+	if err != nil {
+		return err
+	}
+	verIncidentes(ctx.Salida, ctx.Incidentes, ctx.Redes, desde, hasta)
+	return nil
+}
+
+// PRE: el contexto debe de existir
+// POST: muestra si la IP indicada probablemente fue vista en los logs o los filtros cargados
+func ejecutarYaVisto(ctx *Contexto, parametros []string) error {
+	ip, err := parsearIP(parametros[0])
+	if err != nil {
+		return err
+	}
+	yaVisto(ctx.Salida, ctx.Dataset, ip)
+	return nil
+}
+
+// PRE: el contexto debe de existir
+// POST: guarda en el archivo un filtro de Bloom sin contadores con las IPs del dataset actual, reemplazandolo si existe
+func ejecutarGuardarFiltroVistos(ctx *Contexto, parametros []string) error {
+	filtro := TDABLOOM.CrearFiltroBloom(max(ctx.Arbol.Cantidad(), 1), TASA_FP_IPS_VISTAS)
+	for ip := range ctx.Arbol.Todos() {
+		filtro.Agregar(claveIP(ip))
+	}
+	if err := os.WriteFile(parametros[0], filtro.Serializar(), 0o644); err != nil {
+		return errorAbrirArchivo(parametros[0], err)
+	}
+	fmt.Fprintln(ctx.Salida, "OK")
+	return nil
+}
+
+// PRE: el contexto debe de existir
+// POST: agrega al dataset actual una carga con el filtro guardado en el archivo. Si el archivo no se puede leer o no
+// fue generado por guardar_filtro_vistos retorna un error y no carga nada
+func ejecutarCargarFiltroVistos(ctx *Contexto, parametros []string) error {
+	datos, err := os.ReadFile(parametros[0])
+	if err != nil {
+		return errorAbrirArchivo(parametros[0], err)
+	}
+	filtro, err := TDABLOOM.CargarFiltroBloom(datos)
+	if err != nil {
+		return errorDetallado(ErrArchivoInvalido, "%s: no fue generado por guardar_filtro_vistos", parametros[0])
+	}
+	ctx.agregarCargaFiltro(parametros[0], filtro)
+	fmt.Fprintln(ctx.Salida, "OK")
+	return nil
+}
+
+// PRE: el contexto debe de existir
+// POST: carga las redes del archivo en el trie de redes del contexto
+func ejecutarCargarRedes(ctx *Contexto, parametros []string) error {
+	file, err := AbrirArchivo(parametros[0])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err := cargarRedes(ctx.Redes, file); err != nil {
+		return err
+	}
+	fmt.Fprintln(ctx.Salida, "OK")
+	return nil
+}
+
+// PRE: el contexto debe de existir
+// POST: quita la ultima carga del archivo y muestra los archivos quitados
+func ejecutarQuitarArchivo(ctx *Contexto, parametros []string) error {
+	quitados, ok := ctx.quitarArchivo(parametros[0])
+	if !ok {
+		return errorDetallado(ErrArchivoNoCargado, "%s", parametros[0])
+	}
+	fmt.Fprintf(ctx.Salida, "Archivos quitados: %s\n", strings.Join(quitados, ", "))
+	fmt.Fprintln(ctx.Salida, "OK")
+	return nil
+}
+
+// PRE: el contexto debe de existir
+// POST: descarta todos los logs cargados en el dataset actual
+func ejecutarLimpiar(ctx *Contexto, _ []string) error {
+	ctx.Limpiar()
+	fmt.Fprintln(ctx.Salida, "OK")
+	return nil
+}
+
+// PRE: el arbol debe de existir, con las IPs inicializadas y ordenadas, y el trie de redes debe de existir. desde debe de ser menor o igual a hasta
+// POST: itera el ABB y muestra las IPs dentro del rango especificado por parametro, con la etiqueta de su red si la tienen
+func verVisitantes(salida io.Writer, arbol TDADICC.DiccionarioOrdenado[uint32, int], redes TDATRIEIP.TrieIP[string], desde, hasta uint32) {
+	fmt.Fprintln(salida, "Visitantes:")
+	for clave := range arbol.TodosRango(&desde, &hasta) {
+		fmt.Fprintf(salida, "\t%s\n", etiquetarIP(redes, clave))
 	}
-	return false
+	fmt.Fprintln(salida, "OK")
 }
 
-// PRE: debe de existir el hash con la información inicializada.
-// POST: muestra los N recursos más solicitados en el log.
-func verMasVisitados(n int, recursos TDADICC.Diccionario[string, int]) {
-	heap := TDAHEAP.CrearHeap[recursoConConteo](compararRecursos)
+// PRE: recursos debe de recorrer los recursos con su conteo de visitas.
+// POST: muestra los N recursos más solicitados en el log. Si se indica un prefijo, se muestra en el encabezado.
+func verMasVisitados(salida io.Writer, n int, recursos iter.Seq2[string, int], prefijo string) {
+	heap := TDAHEAP.CrearHeap[recursoConConteo](compararMasVisitados)
 
-	recursos.Iterar(func(recurso string, conteo int) bool {
+	for recurso, conteo := range recursos {
+		candidato := recursoConConteo{recurso: recurso, conteo: conteo}
 		if heap.Cantidad() < n {
-			heap.Encolar(recursoConConteo{recurso: recurso, conteo: conteo})
-		} else if conteo > heap.VerMax().conteo {
+			heap.Encolar(candidato)
+		} else if n > 0 && compararMasVisitados(candidato, heap.VerMax()) < 0 {
 			heap.Desencolar()
-			heap.Encolar(recursoConConteo{recurso: recurso, conteo: conteo})
+			heap.Encolar(candidato)
+		}
+	}
+
+	// El heap se desencola del menos visitado al mas visitado, asi que se ordenan de mayor a menor, y los empates por nombre
+	masVisitados := make([]recursoConConteo, 0, heap.Cantidad())
+	for masVisitado := range heap.DesencolarTodos() {
+		masVisitados = append(masVisitados, masVisitado)
+	}
+	TDAORD.MergeSort(masVisitados, compararMasVisitados)
+	if prefijo == "" {
+		fmt.Fprintln(salida, "Sitios más visitados:")
+	} else {
+		fmt.Fprintf(salida, "Sitios más visitados en %s:\n", prefijo)
+	}
+	for _, masVisitado := range masVisitados {
+		fmt.Fprintf(salida, "\t%s - %d\n", masVisitado.recurso, masVisitado.conteo)
+	}
+	fmt.Fprintln(salida, "OK")
+}
+
+// PRE: debe de existir el TopK con la información inicializada.
+// POST: muestra los N recursos más solicitados según la estimación del TopK, junto con el error máximo de cada conteo.
+func verMasVisitadosAprox(salida io.Writer, n int, topK TDATOPK.TopK[string]) {
+	fmt.Fprintln(salida, "Sitios más visitados (aproximado):")
+	topK.IterarMayores(n, func(recurso string, conteo, errorMax int) bool {
+		fmt.Fprintf(salida, "\t%s - %d (error <= %d)\n", recurso, conteo, errorMax)
+		return true
+	})
+	fmt.Fprintln(salida, "OK")
+}
+
+// PRE: prefijo debe de ser una ruta de recurso
+// POST: retorna el prefijo sin la barra final, para que abarque al directorio completo. La raiz "/" pasa a ser "", que abarca a todos los recursos
+func normalizarPrefijo(prefijo string) string {
+	return strings.TrimSuffix(prefijo, TDADICC.SEPARADOR_RUTA)
+}
+
+// PRE: el diccionario de recursos debe de existir con la información inicializada.
+// POST: muestra cada ruta hasta la profundidad indicada con la suma de visitas de todos los recursos bajo ella. Las
+// sumas se calculan en un unico recorrido del trie, sin volver a recorrer el subarbol de cada ruta
+func verArbolRecursos(salida io.Writer, recursos TDADICC.DiccionarioRutas[int], profundidad int) {
+	fmt.Fprintln(salida, "Arbol de recursos:")
+	sumar := func(acumulado, conteo int) int { return acumulado + conteo }
+	recursos.IterarNodosAcumulados(profundidad, sumar, func(ruta string, nivel, visitas int) bool {
+		// El nivel 0 es la raiz de las rutas absolutas, que abarca a todos los recursos
+		if nivel == 0 {
+			return true
 		}
+		fmt.Fprintf(salida, "%s%s - %d\n", strings.Repeat("\t", nivel), ruta, visitas)
 		return true
 	})
+	fmt.Fprintln(salida, "OK")
+}
+
+// PRE: err debe de ser el error de convertir nStr a entero
+// POST: Devuelve un error de cantidad invalida si `err` no es nil o la cantidad es negativa. Devuelve nil en caso contrario.
+func errorCantidad(err error, nStr string) error {
+	if err != nil || strings.HasPrefix(nStr, "-") {
+		return errorDetallado(ErrNInvalido, "%q no es un entero no negativo", nStr)
+	}
+	return nil
+}
+
+// PRE: err debe de ser el error de convertir profundidadStr a entero
+// POST: Devuelve un error de cantidad invalida si `err` no es nil o la profundidad es menor a 1. Devuelve nil en caso contrario.
+func errorVerArbolRecursos(err error, profundidad int, profundidadStr string) error {
+	if err != nil || profundidad < 1 {
+		return errorDetallado(ErrNInvalido, "%q no es una profundidad mayor o igual a 1", profundidadStr)
+	}
+	return nil
+}
 
-	// Mostramos los resultados en el orden en que fueron extraídos.
-	fmt.Println("Sitios más visitados:")
-	for i := 0; i < n && !heap.EstaVacia(); i++ {
-		masVisitado := heap.Desencolar()
-		fmt.Printf("\t%s - %d\n", masVisitado.recurso, masVisitado.conteo)
+// PRE: err debe de ser el error de convertir nStr a entero
+// POST: Devuelve un error si la cantidad no es un entero no negativo o el modo no es valido. Devuelve nil en caso contrario.
+func errorVerMasVisitados(err error, nStr, modo string) error {
+	if err := errorCantidad(err, nStr); err != nil {
+		return err
 	}
-	fmt.Println("OK")
+	if modo != "" && modo != MODO_APROXIMADO {
+		return errorDetallado(ErrParametroInvalido, "modo desconocido %q", modo)
+	}
+	return nil
 }
 
-// PRE: se debe de pasar como parametro un comando valido
-// POST: Devuelve `true` si `err` no es nil y escribe un mensaje de error en stderr. Devuelve `false` en caso contrario.
-func errorVerMasVisitados(err error, comando string) bool {
+// PRE: desdeStr y hastaStr deben de estar en el formato de los logs. hastaStr puede ser vacio
+// POST: retorna el rango de tiempo [desde, hasta]. Si hastaStr es vacio el rango es el instante desde
+func parsearRangoTiempo(desdeStr, hastaStr string) (time.Time, time.Time, error) {
+	desde, err := time.Parse(LAYOUT, desdeStr)
+	if err != nil {
+		return desde, desde, errorDetallado(ErrParametroInvalido, "fecha invalida %q", desdeStr)
+	}
+	if hastaStr == "" {
+		return desde, desde, nil
+	}
+	hasta, err := time.Parse(LAYOUT, hastaStr)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error en comando %s\n", comando)
+		return desde, hasta, errorDetallado(ErrParametroInvalido, "fecha invalida %q", hastaStr)
+	}
+	if hasta.Before(desde) {
+		return desde, hasta, errorDetallado(ErrParametroInvalido, "el rango de tiempo esta invertido")
+	}
+	return desde, hasta, nil
+}
+
+// PRE: el arbol de incidentes y el trie de redes deben de existir
+// POST: muestra, ordenados por inicio, los incidentes de DoS que se solapan con el rango [desde, hasta]
+func verIncidentes(salida io.Writer, incidentes TDAINTERVALOS.ArbolIntervalos[time.Time, string], redes TDATRIEIP.TrieIP[string], desde, hasta time.Time) {
+	fmt.Fprintln(salida, "Incidentes:")
+	incidentes.IterarSolapados(desde, hasta, func(inicio, fin time.Time, ip string) bool {
+		fmt.Fprintf(salida, "\t%s: %s - %s\n", etiquetarIP(redes, ipStringANumero(ip)), inicio.Format(LAYOUT), fin.Format(LAYOUT))
 		return true
+	})
+	fmt.Fprintln(salida, "OK")
+}
+
+// PRE: el dataset debe de existir
+// POST: muestra si la IP probablemente fue vista en algun log del dataset o, si no, en el primer filtro cargado que la
+// tenga, junto con la probabilidad de que sea un falso positivo, o si seguro no fue vista
+func yaVisto(salida io.Writer, d *Dataset, ip uint32) {
+	if d.Vistos.puedePertenecer(ip) {
+		fmt.Fprintf(salida, "%s: visto (probabilidad de falso positivo: %.4f%%)\n", ipAString(ip), d.Vistos.filtro.TasaFalsosPositivos()*100)
+	} else if archivo, filtro, ok := d.filtroCargadoCon(claveIP(ip)); ok {
+		fmt.Fprintf(salida, "%s: visto en %s (probabilidad de falso positivo: %.4f%%)\n", ipAString(ip), archivo, filtro.TasaFalsosPositivos()*100)
+	} else {
+		fmt.Fprintf(salida, "%s: no visto\n", ipAString(ip))
+	}
+	fmt.Fprintln(salida, "OK")
+}
+
+// PRE:
+// POST: Devuelve un error de parametro invalido si el modo no es valido. Devuelve nil en caso contrario.
+func errorAgregarArchivo(modo string) error {
+	if modo != "" && modo != MODO_ESTRICTO {
+		return errorDetallado(ErrParametroInvalido, "modo desconocido %q", modo)
 	}
-	return false
+	return nil
 }
@@ -0,0 +1,299 @@
+package operComandos
+
+import (
+	"io"
+	"iter"
+	"os"
+	"path/filepath"
+	"slices"
+	TDAINTERVALOS "tdas/arbol_intervalos"
+	TDABLOOM "tdas/bloom"
+	TDADICC "tdas/diccionario"
+	TDATOPK "tdas/top_k"
+	TDATRIEIP "tdas/trie_ip"
+	"time"
+)
+
+const DATASET_INICIAL = "principal"
+
+// Contexto de analisis compartido por todos los comandos: los datasets con la informacion de los logs cargados y
+// las redes, que son comunes a todos. Los comandos se aplican al dataset actual, cuyos campos se acceden
+// directamente desde el contexto. Los comandos propios reciben el mismo contexto que los predefinidos
+type Contexto struct {
+	*Dataset
+	Redes TDATRIEIP.TrieIP[string]
+	// Destino de la salida de los comandos, por defecto la salida estandar
+	Salida   io.Writer
+	datasets TDADICC.Diccionario[string, *Dataset]
+	// Nombres de los datasets en el orden en que se crearon
+	nombres []string
+}
+
+// Conjunto de logs cargados con nombre, con las estructuras de sus analisis
+type Dataset struct {
+	Nombre string
+	// Si es true el dataset no guarda el conteo exacto de cada recurso sino solo el TopK, con memoria acotada sin
+	// importar cuantos recursos distintos haya. Se elige al crearlo y Recursos queda en nil
+	Aproximado bool
+	Recursos   TDADICC.DiccionarioRutas[int]
+	// IPs visitantes, con la cantidad de cargas de logs en las que aparecen
+	Arbol           TDADICC.DiccionarioOrdenado[uint32, int]
+	Vistos          *filtroIPs
+	TopK            TDATOPK.TopK[string]
+	VisitantesAprox *VisitantesAprox
+	Incidentes      TDAINTERVALOS.ArbolIntervalos[time.Time, string]
+	// Cargas de logs en el orden en que se hicieron, con lo que aporto cada una para poder quitarla
+	cargas []*carga
+}
+
+// Lo que aporto una carga de logs al contexto: la de un archivo, o la de varios archivos fusionados
+type carga struct {
+	archivos []string
+	// Conteos exactos de los recursos, o nil si el dataset es aproximado
+	recursos TDADICC.Diccionario[string, int]
+	// TopK propio de la carga, solo si el dataset es aproximado, para poder reconstruir el del dataset al quitarla
+	topK            TDATOPK.TopK[string]
+	ips             []uint32
+	visitantesAprox *VisitantesAprox
+	// Filtro de IPs vistas cargado con cargar_filtro_vistos, o nil si la carga es de logs
+	filtro     TDABLOOM.FiltroBloom
+	incidentes []incidente
+}
+
+// Rafaga de DoS de una IP, tal como se guarda en el arbol de incidentes
+type incidente struct {
+	rafaga rafagaDoS
+	ip     string
+}
+
+// PRE:
+// POST: crea un contexto de analisis sin logs ni redes cargadas, con el dataset DATASET_INICIAL vacio como actual
+func CrearContexto() *Contexto {
+	ctx := &Contexto{
+		Redes:    TDATRIEIP.CrearTrieIP[string](),
+		Salida:   os.Stdout,
+		datasets: TDADICC.CrearHash[string, *Dataset](),
+	}
+	ctx.CrearDataset(DATASET_INICIAL, false)
+	return ctx
+}
+
+// PRE:
+// POST: crea un dataset vacio con el nombre indicado, exacto o aproximado
+func crearDatasetVacio(nombre string, aproximado bool) *Dataset {
+	d := &Dataset{Nombre: nombre, Aproximado: aproximado}
+	d.Limpiar()
+	return d
+}
+
+// PRE: el contexto debe de existir
+// POST: crea un dataset vacio con el nombre indicado, exacto o aproximado, y lo hace el actual. Si ya existe uno con
+// ese nombre retorna false
+func (ctx *Contexto) CrearDataset(nombre string, aproximado bool) bool {
+	if ctx.datasets.Pertenece(nombre) {
+		return false
+	}
+	ctx.datasets.Guardar(nombre, crearDatasetVacio(nombre, aproximado))
+	ctx.nombres = append(ctx.nombres, nombre)
+	return ctx.UsarDataset(nombre)
+}
+
+// PRE: el contexto debe de existir
+// POST: hace actual al dataset con el nombre indicado. Si no existe retorna false
+func (ctx *Contexto) UsarDataset(nombre string) bool {
+	if !ctx.datasets.Pertenece(nombre) {
+		return false
+	}
+	ctx.Dataset = ctx.datasets.Obtener(nombre)
+	return true
+}
+
+// PRE: el contexto debe de existir
+// POST: retorna el dataset con el nombre indicado y true, o false si no existe
+func (ctx *Contexto) ObtenerDataset(nombre string) (*Dataset, bool) {
+	if !ctx.datasets.Pertenece(nombre) {
+		return nil, false
+	}
+	return ctx.datasets.Obtener(nombre), true
+}
+
+// PRE: el contexto debe de existir
+// POST: retorna una secuencia con los datasets, en el orden en que se crearon
+func (ctx *Contexto) Datasets() iter.Seq[*Dataset] {
+	return func(yield func(*Dataset) bool) {
+		for _, nombre := range ctx.nombres {
+			if !yield(ctx.datasets.Obtener(nombre)) {
+				return
+			}
+		}
+	}
+}
+
+// PRE: el dataset debe de existir
+// POST: descarta todos los logs cargados en el dataset, dejandolo como recien creado y con el mismo modo
+func (d *Dataset) Limpiar() {
+	d.Recursos = nil
+	if !d.Aproximado {
+		d.Recursos = TDADICC.CrearDiccionarioRutas[int]()
+	}
+	d.Arbol = TDADICC.CrearABB[uint32, int](CompararIPs)
+	d.Vistos = crearFiltroIPs(CAPACIDAD_IPS_VISTAS)
+	d.TopK = TDATOPK.CrearSpaceSaving[string](CAPACIDAD_TOPK_APROX)
+	d.VisitantesAprox = CrearVisitantesAprox()
+	d.Incidentes = TDAINTERVALOS.CrearArbolIntervalos[time.Time, string](time.Time.Compare)
+	d.cargas = nil
+}
+
+// PRE: el dataset debe de existir
+// POST: retorna true si todavia no se cargo ningun log. Un log vacio o con todas sus lineas invalidas cuenta como
+// cargado, y las consultas sobre el responden sin resultados
+func (d *Dataset) sinDatos() bool {
+	return len(d.cargas) == 0
+}
+
+// PRE: el dataset debe de existir
+// POST: retorna la cantidad de cargas de logs del dataset
+func (d *Dataset) CantidadCargas() int {
+	return len(d.cargas)
+}
+
+// PRE: el dataset debe de existir
+// POST: retorna una secuencia con los recursos y su cantidad de visitas: los conteos exactos, o en un dataset
+// aproximado los conteos estimados de los recursos monitoreados por el TopK
+func (d *Dataset) conteosRecursos() iter.Seq2[string, int] {
+	if !d.Aproximado {
+		return d.Recursos.Todos()
+	}
+	return func(yield func(string, int) bool) {
+		d.TopK.IterarMayores(d.TopK.Cantidad(), func(recurso string, conteo, _ int) bool {
+			return yield(recurso, conteo)
+		})
+	}
+}
+
+// PRE: las lineas deben de ser las de los archivos indicados, estar en orden cronologico y poder recorrerse mas de una vez
+// POST: agrega las lineas a todos los analisis del dataset actual, registrando lo que aportaron como una carga, y
+// muestra los sospechosos de DoS encontrados en ellas
+func (ctx *Contexto) analizarLineas(archivos []string, lineas iter.Seq[string]) {
+	c := ctx.crearCarga(archivos, CrearVisitantesAprox())
+	c.ips = actualizarIPS(ctx.Arbol, ctx.Vistos, lineas)
+	if ctx.Aproximado {
+		actualizarRecursos(func(recurso string) {
+			c.topK.Agregar(recurso)
+			ctx.TopK.Agregar(recurso)
+		}, c.visitantesAprox, lineas)
+	} else {
+		actualizarRecursos(func(recurso string) {
+			incrementarRecurso(c.recursos, recurso)
+			ctx.TopK.Agregar(recurso)
+		}, c.visitantesAprox, lineas)
+		sumarRecursos(ctx.Recursos, c.recursos)
+	}
+	// Los visitantes por recurso solo se guardan para los mas visitados, que se conocen al terminar de contarlos
+	c.visitantesAprox.agregarRecursos(lineas, recursosSeguidos(ctx.TopK))
+	ctx.VisitantesAprox.unir(c.visitantesAprox)
+	c.incidentes = sospechososDoS(ctx.Salida, lineas, ctx.Redes)
+	for _, inc := range c.incidentes {
+		ctx.Incidentes.Insertar(inc.rafaga.Inicio, inc.rafaga.Fin, inc.ip)
+	}
+	ctx.cargas = append(ctx.cargas, c)
+}
+
+// PRE: el dataset debe de existir
+// POST: retorna una carga vacia de los archivos indicados con los visitantes aproximados dados, con los conteos
+// exactos o el TopK propio segun el modo del dataset
+func (d *Dataset) crearCarga(archivos []string, visitantesAprox *VisitantesAprox) *carga {
+	c := &carga{archivos: make([]string, len(archivos)), visitantesAprox: visitantesAprox}
+	for i, archivo := range archivos {
+		c.archivos[i] = filepath.Clean(archivo)
+	}
+	if d.Aproximado {
+		c.topK = TDATOPK.CrearSpaceSaving[string](CAPACIDAD_TOPK_APROX)
+	} else {
+		c.recursos = TDADICC.CrearHash[string, int]()
+	}
+	return c
+}
+
+// PRE: el dataset y los visitantes aproximados deben de existir
+// POST: agrega al dataset una carga del archivo que solo aporta los visitantes aproximados, uniendolos a los del
+// dataset. Se puede quitar como cualquier otra carga
+func (d *Dataset) agregarCargaVisitantes(archivo string, visitantesAprox *VisitantesAprox) {
+	c := d.crearCarga([]string{archivo}, visitantesAprox)
+	d.VisitantesAprox.unir(visitantesAprox)
+	d.cargas = append(d.cargas, c)
+}
+
+// PRE: el dataset y el filtro deben de existir
+// POST: agrega al dataset una carga del archivo que solo aporta el filtro de IPs vistas, que ya_visto consulta
+// ademas del filtro del dataset. Se puede quitar como cualquier otra carga
+func (d *Dataset) agregarCargaFiltro(archivo string, filtro TDABLOOM.FiltroBloom) {
+	c := d.crearCarga([]string{archivo}, CrearVisitantesAprox())
+	c.filtro = filtro
+	d.cargas = append(d.cargas, c)
+}
+
+// PRE: el dataset debe de existir
+// POST: retorna el archivo y el filtro de la primera carga de filtro que puede tener la clave, y false si ninguna
+func (d *Dataset) filtroCargadoCon(clave string) (string, TDABLOOM.FiltroBloom, bool) {
+	for _, c := range d.cargas {
+		if c.filtro != nil && c.filtro.PuedePertenecer(clave) {
+			return c.archivos[0], c.filtro, true
+		}
+	}
+	return "", nil, false
+}
+
+// PRE: el dataset debe de existir
+// POST: quita la ultima carga que incluye al archivo, restando sus recursos y sus IPs, y retorna los archivos de esa
+// carga. Si el archivo no esta cargado retorna false
+func (d *Dataset) quitarArchivo(archivo string) ([]string, bool) {
+	archivo = filepath.Clean(archivo)
+	i := len(d.cargas) - 1
+	for i >= 0 && !slices.Contains(d.cargas[i].archivos, archivo) {
+		i--
+	}
+	if i < 0 {
+		return nil, false
+	}
+	c := d.cargas[i]
+	d.cargas = slices.Delete(d.cargas, i, i+1)
+	if !d.Aproximado {
+		restarRecursos(d.Recursos, c.recursos)
+	}
+	quitarIPs(d.Arbol, d.Vistos, c.ips)
+	d.reconstruirAproximados()
+	return c.archivos, true
+}
+
+// PRE: el dataset debe de existir y, si es exacto, los recursos ya deben de tener los conteos de las cargas actuales
+// POST: reconstruye las estructuras que no permiten restar a partir de las cargas actuales: el TopK, los visitantes
+// aproximados uniendo los de cada carga y el arbol de incidentes.
+// En un dataset exacto el TopK se reconstruye en O(r log m) para r recursos distintos, agregando cada conteo exacto de
+// una vez. Como recibe los conteos agrupados en lugar del flujo original, sus errores no son los que tendria si esas
+// cargas se hubieran hecho desde cero, aunque siguen acotados por N/m. En un dataset aproximado se suman los TopK de
+// cada carga, cuyos conteos ya tienen su propio error, por lo que el conteo reconstruido puede diferir del real en
+// hasta 2N/m en cualquier direccion y el error que informa deja de ser una cota
+func (d *Dataset) reconstruirAproximados() {
+	d.TopK = TDATOPK.CrearSpaceSaving[string](CAPACIDAD_TOPK_APROX)
+	if d.Aproximado {
+		for _, c := range d.cargas {
+			c.topK.IterarMayores(c.topK.Cantidad(), func(recurso string, conteo, _ int) bool {
+				d.TopK.AgregarN(recurso, conteo)
+				return true
+			})
+		}
+	} else {
+		for recurso, conteo := range d.Recursos.Todos() {
+			d.TopK.AgregarN(recurso, conteo)
+		}
+	}
+	d.VisitantesAprox = CrearVisitantesAprox()
+	d.Incidentes = TDAINTERVALOS.CrearArbolIntervalos[time.Time, string](time.Time.Compare)
+	for _, c := range d.cargas {
+		d.VisitantesAprox.unir(c.visitantesAprox)
+		for _, inc := range c.incidentes {
+			d.Incidentes.Insertar(inc.rafaga.Inicio, inc.rafaga.Fin, inc.ip)
+		}
+	}
+}
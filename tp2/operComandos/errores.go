@@ -0,0 +1,56 @@
+package operComandos
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+)
+
+// Tipos de error de los comandos. Los errores que devuelve ProcesarEntrada envuelven a uno de estos, por lo
+// que se pueden distinguir con errors.Is, y agregan el detalle de lo que fallo
+var (
+	ErrArchivoInexistente = errors.New("el archivo no existe")
+	ErrPermisoDenegado    = errors.New("permiso denegado")
+	ErrArchivoInvalido    = errors.New("archivo invalido")
+	ErrArchivoNoCargado   = errors.New("el archivo no esta cargado")
+	ErrDatasetExistente   = errors.New("el dataset ya existe")
+	ErrDatasetInexistente = errors.New("el dataset no existe")
+	ErrDatasetAproximado  = errors.New("el dataset solo guarda conteos aproximados")
+	ErrIPInvalida         = errors.New("IP invalida")
+	ErrNInvalido          = errors.New("cantidad invalida")
+	ErrParametroInvalido  = errors.New("parametro invalido")
+	ErrSinDatos           = errors.New("todavia no se cargo ningun archivo")
+	ErrComandoDesconocido = errors.New("comando no reconocido")
+)
+
+// Error de un comando: el comando que fallo y la causa, que envuelve a uno de los tipos de error
+type ErrorComando struct {
+	Comando string
+	Err     error
+}
+
+func (e *ErrorComando) Error() string {
+	return fmt.Sprintf("Error en comando %s: %v", e.Comando, e.Err)
+}
+
+func (e *ErrorComando) Unwrap() error {
+	return e.Err
+}
+
+// PRE: tipo debe de ser uno de los tipos de error de los comandos
+// POST: retorna un error del tipo indicado con el detalle agregado
+func errorDetallado(tipo error, formato string, argumentos ...any) error {
+	return fmt.Errorf("%w: %s", tipo, fmt.Sprintf(formato, argumentos...))
+}
+
+// PRE: err debe de ser el error devuelto al abrir el archivo
+// POST: retorna el error tipado correspondiente: archivo inexistente, permiso denegado o archivo invalido
+func errorAbrirArchivo(archivo string, err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return errorDetallado(ErrArchivoInexistente, "%s", archivo)
+	}
+	if errors.Is(err, fs.ErrPermission) {
+		return errorDetallado(ErrPermisoDenegado, "%s", archivo)
+	}
+	return errorDetallado(ErrArchivoInvalido, "%s: %v", archivo, err)
+}
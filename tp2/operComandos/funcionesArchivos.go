@@ -0,0 +1,213 @@
+package operComandos
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"io"
+	"iter"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	TDAHEAP "tdas/cola_prioridad"
+	"time"
+)
+
+const (
+	CAMPOS_LINEA                 = 4
+	MAX_LINEAS_INVALIDAS_MOSTRAR = 5
+	MODO_ESTRICTO                = "estricto"
+)
+
+// Lineas invalidas de un archivo: cuantas son y los numeros de las primeras, para poder encontrarlas
+type reporteLineas struct {
+	archivo   string
+	invalidas int
+	primeras  []int
+}
+
+type lineaConTiempo struct {
+	linea   string
+	tiempo  time.Time
+	archivo int
+}
+
+// PRE: 'archivos' debe de tener rutas a archivos que se puedan abrir en modo lectura
+// POST: devuelve los archivos abiertos. Si alguno no se puede abrir, cierra los que ya abrio y devuelve nil y el error tipado correspondiente
+func abrirArchivos(archivos []string) ([]*os.File, error) {
+	files := make([]*os.File, 0, len(archivos))
+	for _, archivo := range archivos {
+		file, err := AbrirArchivo(archivo)
+		if err != nil {
+			cerrarArchivos(files)
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// PRE: los archivos deben de estar abiertos
+// POST: cierra todos los archivos
+func cerrarArchivos(files []*os.File) {
+	for _, file := range files {
+		file.Close()
+	}
+}
+
+// PRE: err no debe de ser nil y causa debe de ser el error de leer el archivo o volver a su principio, o nil
+// POST: si hubo un error y err todavia no tenia otro, guarda en err el error de archivo invalido correspondiente.
+// Retorna true si hubo un error
+func guardarErrorLectura(err *error, file *os.File, causa error) bool {
+	if causa == nil {
+		return false
+	}
+	if *err == nil {
+		*err = errorDetallado(ErrArchivoInvalido, "%s: %v", file.Name(), causa)
+	}
+	return true
+}
+
+// PRE: el archivo debe de estar abierto en modo lectura y err no debe de ser nil
+// POST: vuelve al principio del archivo y retorna un scanner sobre el. Si no puede, guarda el error en err y retorna nil
+func rebobinar(file *os.File, err *error) *bufio.Scanner {
+	if _, causa := file.Seek(0, io.SeekStart); guardarErrorLectura(err, file, causa) {
+		return nil
+	}
+	return bufio.NewScanner(file)
+}
+
+// PRE: el archivo debe de estar abierto en modo lectura y err no debe de ser nil
+// POST: retorna un iterador sobre las lineas del archivo. Cada recorrido vuelve a leer el archivo desde el principio;
+// si no puede volver al principio o falla la lectura, el recorrido termina y el error queda en err
+func lineasArchivo(file *os.File, err *error) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		scanner := rebobinar(file, err)
+		if scanner == nil {
+			return
+		}
+		for scanner.Scan() {
+			if !yield(scanner.Text()) {
+				return
+			}
+		}
+		guardarErrorLectura(err, file, scanner.Err())
+	}
+}
+
+// PRE:
+// POST: retorna true si la linea tiene los campos de una peticion: una IPv4, una fecha con el formato LAYOUT, un metodo y un recurso, separados por tabs
+func lineaValida(linea string) bool {
+	campos := strings.Split(linea, "\t")
+	if len(campos) < CAMPOS_LINEA || campos[3] == "" || net.ParseIP(campos[0]).To4() == nil {
+		return false
+	}
+	_, err := time.Parse(LAYOUT, campos[1])
+	return err == nil
+}
+
+// PRE: lineas debe de recorrer las lineas de un archivo desde el principio
+// POST: retorna cuantas lineas invalidas tiene el archivo y los numeros de las primeras MAX_LINEAS_INVALIDAS_MOSTRAR
+func validarLineas(lineas iter.Seq[string], archivo string) reporteLineas {
+	reporte := reporteLineas{archivo: archivo}
+	numero := 0
+	for linea := range lineas {
+		numero++
+		if lineaValida(linea) {
+			continue
+		}
+		reporte.invalidas++
+		if len(reporte.primeras) < MAX_LINEAS_INVALIDAS_MOSTRAR {
+			reporte.primeras = append(reporte.primeras, numero)
+		}
+	}
+	return reporte
+}
+
+// PRE:
+// POST: si el archivo tiene lineas invalidas, muestra cuantas son y los numeros de las primeras
+func imprimirLineasInvalidas(salida io.Writer, reporte reporteLineas) {
+	if reporte.invalidas == 0 {
+		return
+	}
+	numeros := make([]string, len(reporte.primeras))
+	for i, numero := range reporte.primeras {
+		numeros[i] = strconv.Itoa(numero)
+	}
+	if reporte.invalidas > len(reporte.primeras) {
+		numeros = append(numeros, "...")
+	}
+	fmt.Fprintf(salida, "lineas_invalidas: %d en %s (lineas %s)\n", reporte.invalidas, reporte.archivo, strings.Join(numeros, ", "))
+}
+
+// PRE:
+// POST: retorna un iterador que recorre solo las lineas validas de lineas
+func filtrarValidas(lineas iter.Seq[string]) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for linea := range lineas {
+			if lineaValida(linea) && !yield(linea) {
+				return
+			}
+		}
+	}
+}
+
+// PRE: linea debe de ser una linea de log valida
+// POST: retorna el momento de la peticion
+func tiempoDeLinea(linea string) time.Time {
+	campos := strings.Split(linea, "\t")
+	if len(campos) < 2 {
+		return time.Time{}
+	}
+	tiempo, _ := time.Parse(LAYOUT, campos[1])
+	return tiempo
+}
+
+// PRE: l1 y l2 deben de ser lineas leidas de los archivos a fusionar
+// POST: compara las lineas para que el heap tenga en el tope a la mas antigua, y a igual tiempo a la del primer archivo
+func compararLineas(l1, l2 lineaConTiempo) int {
+	if comparacion := l2.tiempo.Compare(l1.tiempo); comparacion != 0 {
+		return comparacion
+	}
+	return cmp.Compare(l2.archivo, l1.archivo)
+}
+
+// PRE: el scanner debe de corresponder al archivo de indice 'archivo'
+// POST: si al archivo le quedan lineas validas, encola la siguiente en el heap. Las lineas invalidas se saltean
+func encolarSiguiente(heap TDAHEAP.ColaPrioridad[lineaConTiempo], scanner *bufio.Scanner, archivo int) {
+	for scanner.Scan() {
+		linea := scanner.Text()
+		if lineaValida(linea) {
+			heap.Encolar(lineaConTiempo{linea: linea, tiempo: tiempoDeLinea(linea), archivo: archivo})
+			return
+		}
+	}
+}
+
+// PRE: los archivos deben de estar abiertos en modo lectura y cada uno ordenado cronologicamente
+// POST: retorna un iterador que fusiona las lineas validas de todos los archivos en orden cronologico, manteniendo en un heap
+// solo la proxima linea de cada archivo. Cada recorrido vuelve a leer los archivos desde el principio; si no puede
+// volver al principio de alguno o falla la lectura, el recorrido termina y el error queda en err, que no debe de ser nil
+func lineasFusionadas(files []*os.File, err *error) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		heap := TDAHEAP.CrearHeap[lineaConTiempo](compararLineas)
+		scanners := make([]*bufio.Scanner, len(files))
+		for i, file := range files {
+			if scanners[i] = rebobinar(file, err); scanners[i] == nil {
+				return
+			}
+			encolarSiguiente(heap, scanners[i], i)
+		}
+		for !heap.EstaVacia() {
+			masAntigua := heap.Desencolar()
+			if !yield(masAntigua.linea) {
+				return
+			}
+			encolarSiguiente(heap, scanners[masAntigua.archivo], masAntigua.archivo)
+		}
+		for i, scanner := range scanners {
+			guardarErrorLectura(err, files[i], scanner.Err())
+		}
+	}
+}
@@ -1,18 +1,29 @@
 package operComandos
 
 import (
-	"bufio"
 	"fmt"
-	"os"
+	"io"
+	"iter"
 	"strings"
+	TDAINTERVALOS "tdas/arbol_intervalos"
 	TDADICC "tdas/diccionario"
+	TDAORD "tdas/ordenamiento"
+	TDATRIEIP "tdas/trie_ip"
 	"time"
 )
 
 const (
-	LAYOUT               = "2006-01-02T15:04:05-07:00"
-	CAPMINARRSOSPECHOSOS = 10
-	FACTOR_EXPANSION     = 2
+	LAYOUT                = "2006-01-02T15:04:05-07:00"
+	CAPMINARRSOSPECHOSOS  = 10
+	FACTOR_EXPANSION      = 2
+	CAPACIDAD_TOPK_APROX  = 1000
+	MODO_APROXIMADO       = "aprox"
+	PRECISION_HLL_TOTAL   = 14
+	PRECISION_HLL_RECURSO = 10
+	PRECISION_HLL_HORA    = 10
+	MAX_RECURSOS_HLL      = 100
+	CAPACIDAD_IPS_VISTAS  = 1024
+	TASA_FP_IPS_VISTAS    = 0.001
 )
 
 type recursoConConteo struct {
@@ -20,12 +31,24 @@ type recursoConConteo struct {
 	conteo  int
 }
 
+// Rafaga de peticiones de una IP sospechosa de DoS, desde la primera hasta la ultima peticion de la rafaga
+type rafagaDoS = TDAINTERVALOS.Intervalo[time.Time]
+
 type timestamps struct {
 	times    [5]time.Time
 	index    int
 	contador int
 }
 
+// PRE: r1 y r2 son estructuras de tipo recursoConConteo inicializadas.
+// POST: compara recursos por conteo en orden descendente, y a igual conteo por nombre. Ordena del mas visitado al menos visitado, y como heap deja en el tope al que primero se descarta
+func compararMasVisitados(r1, r2 recursoConConteo) int {
+	if comparacion := compararRecursos(r2, r1); comparacion != 0 {
+		return comparacion
+	}
+	return strings.Compare(r1.recurso, r2.recurso)
+}
+
 // PRE: r1 y r2 son estructuras de tipo recursoConConteo inicializadas.
 // POST: compara recursos por conteo en orden descendente
 func compararRecursos(r1, r2 recursoConConteo) int {
@@ -37,38 +60,57 @@ func compararRecursos(r1, r2 recursoConConteo) int {
 	return 0
 }
 
-// PRE: el hash debe de existir
-// POST: ordena los recursos en un hash con un contador de repeticiones
-func actualizarRecursos(hash TDADICC.Diccionario[string, int], file *os.File) {
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		linea := scanner.Text()
+// PRE: los visitantes aproximados deben de existir y las lineas deben de ser validas
+// POST: llama a contar con el recurso de cada linea, en orden, y registra sus visitantes en el total y en su hora
+func actualizarRecursos(contar func(recurso string), visitantesAprox *VisitantesAprox, lineas iter.Seq[string]) {
+	for linea := range lineas {
 		campo := strings.Split(linea, "\t")
-		recurso := campo[3]
-		if hash.Pertenece(recurso) {
-			conteoActual := hash.Obtener(recurso)
-			hash.Guardar(recurso, conteoActual+1)
-		} else {
-			hash.Guardar(recurso, 1)
+		contar(campo[3])
+		tiempo, _ := time.Parse(LAYOUT, campo[1])
+		visitantesAprox.agregar(campo[0], tiempo)
+	}
+}
+
+// PRE: el hash debe de existir
+// POST: suma una visita al conteo del recurso en el hash
+func incrementarRecurso(hash TDADICC.Diccionario[string, int], recurso string) {
+	conteo := 0
+	if hash.Pertenece(recurso) {
+		conteo = hash.Obtener(recurso)
+	}
+	hash.Guardar(recurso, conteo+1)
+}
+
+// PRE: los recursos y el hash deben de existir
+// POST: suma a los recursos los conteos del hash
+func sumarRecursos(recursos TDADICC.Diccionario[string, int], hash TDADICC.Diccionario[string, int]) {
+	for recurso, conteo := range hash.Todos() {
+		if recursos.Pertenece(recurso) {
+			conteo += recursos.Obtener(recurso)
 		}
+		recursos.Guardar(recurso, conteo)
 	}
 }
 
-// PRE: 'logHash' y 'detectedDoS' son diccionarios válidos
-// POST: Procesa el log, actualiza 'logHash' y registra IPs sospechosas de DoS en 'detectedDoS'.
-func inicializarSospechososDoS(logHash TDADICC.Diccionario[string, timestamps], detectedDoS TDADICC.Diccionario[string, bool], file *os.File) {
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		parts := strings.Fields(line)
-		ip := parts[0]
-		timestamp := parts[1]
-
-		t, err := time.Parse(LAYOUT, timestamp)
-		if err != nil {
-			fmt.Println("Error al parsear la fecha:", err)
-			continue
+// PRE: los recursos deben de incluir los conteos del hash
+// POST: resta a los recursos los conteos del hash, borrando los que quedan sin visitas
+func restarRecursos(recursos TDADICC.Diccionario[string, int], hash TDADICC.Diccionario[string, int]) {
+	for recurso, conteo := range hash.Todos() {
+		if restante := recursos.Obtener(recurso) - conteo; restante > 0 {
+			recursos.Guardar(recurso, restante)
+		} else {
+			recursos.Borrar(recurso)
 		}
+	}
+}
+
+// PRE: 'logHash' y 'detectedDoS' son diccionarios válidos y las lineas deben de ser validas (ya filtradas por
+// filtrarValidas o lineasFusionadas)
+// POST: Procesa el log, actualiza 'logHash' y registra en 'detectedDoS' las rafagas de cada IP sospechosa de DoS.
+func inicializarSospechososDoS(logHash TDADICC.Diccionario[string, timestamps], detectedDoS TDADICC.Diccionario[string, []rafagaDoS], lineas iter.Seq[string]) {
+	for line := range lineas {
+		ip := strings.Fields(line)[0]
+		t := tiempoDeLinea(line)
 
 		if !logHash.Pertenece(ip) {
 			logHash.Guardar(ip, timestamps{index: 0, contador: 0})
@@ -94,16 +136,38 @@ func inicializarSospechososDoS(logHash TDADICC.Diccionario[string, timestamps],
 		}
 
 		if timestamps.contador == 5 && diferencia < 2*time.Second {
-			if !detectedDoS.Pertenece(ip) {
-				detectedDoS.Guardar(ip, true)
-			}
+			// Tras avanzar el indice, la posicion actual tiene la peticion mas antigua de la ventana
+			registrarRafaga(detectedDoS, ip, timestamps.times[timestamps.index], t)
 		}
 	}
 }
 
+// PRE: 'detectedDoS' debe de ser un diccionario válido
+// POST: agrega la rafaga [inicio, fin] a las de la IP. Si se solapa con la ultima rafaga registrada, la extiende en lugar de agregar una nueva
+func registrarRafaga(detectedDoS TDADICC.Diccionario[string, []rafagaDoS], ip string, inicio, fin time.Time) {
+	if fin.Before(inicio) {
+		inicio, fin = fin, inicio
+	}
+	var rafagas []rafagaDoS
+	if detectedDoS.Pertenece(ip) {
+		rafagas = detectedDoS.Obtener(ip)
+	}
+	if ultima := len(rafagas) - 1; ultima >= 0 && !inicio.After(rafagas[ultima].Fin) {
+		if fin.After(rafagas[ultima].Fin) {
+			rafagas[ultima].Fin = fin
+		}
+		if inicio.Before(rafagas[ultima].Inicio) {
+			rafagas[ultima].Inicio = inicio
+		}
+	} else {
+		rafagas = append(rafagas, rafagaDoS{Inicio: inicio, Fin: fin})
+	}
+	detectedDoS.Guardar(ip, rafagas)
+}
+
 // PRE: 'detectedDoS' debe de estar inicializado con las IPs sospechosas de DoS
 // POST: Crea un arreglo de IPs sospechosas desde el diccionario 'detectedDoS' con la cantidad de IPs encontradas.
-func arrayDeSospechososDoS(detectedDoS TDADICC.Diccionario[string, bool]) []string {
+func arrayDeSospechososDoS(detectedDoS TDADICC.Diccionario[string, []rafagaDoS]) []string {
 	// Creo arreglo de tipo string de ips
 	sospechosos := make([]string, CAPMINARRSOSPECHOSOS)
 	i := 0
@@ -128,77 +192,50 @@ func redimensionarArregloDeSospechosos(sospechosos []string, nuevoLargo, nuevaCa
 }
 
 // PRE: arr debe ser un slice de strings que representan direcciones IP validas.
-// POST: retorna un slice con las IPs ordenadas en orden ascendente.
-func radixSort(arr []string) []string {
+// POST: retorna un slice con las IPs ordenadas en orden ascendente, usando radix sort sobre su representacion numerica.
+func ordenarPorIP(arr []string) []string {
 	nums := make([]uint32, len(arr))
 	for i, ip := range arr {
-		num := ipStringANumero(ip)
-
-		nums[i] = num
+		nums[i] = ipStringANumero(ip)
 	}
 
-	nums = countingSort(nums, 24)
+	TDAORD.RadixSort(nums)
 
 	sortedIPs := make([]string, len(arr))
 	for i, num := range nums {
-		sortedIPs[i] = fmt.Sprintf("%d.%d.%d.%d", (num>>24)&0xFF, (num>>16)&0xFF, (num>>8)&0xFF, num&0xFF)
+		sortedIPs[i] = ipAString(num)
 	}
 
 	return sortedIPs
 }
 
-// PRE: arr debe ser un slice de numeros uint32 inicializado y exp debe ser un multiplo de 8 entre 0 y 24.
-// POST: retorna un slice con los numeros ordenados segun el dígito correspondiente al exp.
-func countingSort(arr []uint32, exp int) []uint32 {
-	count := make([]int, 256)
-	output := make([]uint32, len(arr))
-	for _, num := range arr {
-		index := (num >> exp) & 0xFF
-		count[index]++
-	}
-
-	for i := 1; i < len(count); i++ {
-		count[i] += count[i-1]
-	}
-
-	for i := len(arr) - 1; i >= 0; i-- {
-		num := arr[i]
-		index := (num >> exp) & 0xFF
-		output[count[index]-1] = num
-		count[index]--
-	}
-
-	copy(arr, output)
-	return arr
-}
-
-// PRE: el arbol y el hash deben de estar vacios y el archivo debe de estar abierto en modo lectura
-// POST: actualiza el ABB con direcciones IP del archivo y actualiza el hash con los recursos del archivo
-func actualizarIpsYRecursos(arbol TDADICC.DiccionarioOrdenado[uint32, bool], hash TDADICC.Diccionario[string, int], file *os.File) {
-	actualizarIPS(arbol, file)
-	file.Seek(0, 0)
-	actualizarRecursos(hash, file)
-}
-
-// PRE:
-// POST: imprime los sospechosos DoS dentro del arreglo almacenado
-func imprimirSospechosos(sospechosos []string) {
+// PRE: el trie de redes debe de existir
+// POST: imprime los sospechosos DoS dentro del arreglo almacenado, con la etiqueta de su red si la tienen
+func imprimirSospechosos(salida io.Writer, sospechosos []string, redes TDATRIEIP.TrieIP[string]) {
 	for i := 0; i < len(sospechosos); i++ {
-		fmt.Printf("DoS: %s\n", sospechosos[i])
+		fmt.Fprintf(salida, "DoS: %s\n", etiquetarIP(redes, ipStringANumero(sospechosos[i])))
 	}
-	fmt.Println("OK")
+	fmt.Fprintln(salida, "OK")
 }
 
-// PRE: el archivo debe de abrir sin error
-// POST: procesa un archivo de log y detecta los DoS almacenandolos en un hash auxiliar
-func sospechososDoS(file *os.File) {
-	file.Seek(0, 0)
+// PRE: las lineas deben de ser validas y estar en orden cronologico, y el trie de redes debe de existir
+// POST: procesa las lineas de log, detecta los DoS almacenandolos en un hash auxiliar y los muestra. Retorna las rafagas de cada IP como incidentes, ordenados por IP
+func sospechososDoS(salida io.Writer, lineas iter.Seq[string], redes TDATRIEIP.TrieIP[string]) []incidente {
 	logHash := TDADICC.CrearHash[string, timestamps]()
-	detectedDoS := TDADICC.CrearHash[string, bool]()
-	inicializarSospechososDoS(logHash, detectedDoS, file)
+	detectedDoS := TDADICC.CrearHash[string, []rafagaDoS]()
+	inicializarSospechososDoS(logHash, detectedDoS, lineas)
 
 	sospechosos := arrayDeSospechososDoS(detectedDoS)
-	sospechosos = radixSort(sospechosos)
+	sospechosos = ordenarPorIP(sospechosos)
+
+	// Se ordenan por IP para que, al insertarlos en el arbol, los incidentes simultaneos se muestren ordenados por IP
+	var incidentes []incidente
+	for _, ip := range sospechosos {
+		for _, rafaga := range detectedDoS.Obtener(ip) {
+			incidentes = append(incidentes, incidente{rafaga: rafaga, ip: ip})
+		}
+	}
 
-	imprimirSospechosos(sospechosos)
+	imprimirSospechosos(salida, sospechosos, redes)
+	return incidentes
 }
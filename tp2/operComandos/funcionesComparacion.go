@@ -0,0 +1,279 @@
+package operComandos
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	TDADICC "tdas/diccionario"
+	TDAORD "tdas/ordenamiento"
+	TDATRIEIP "tdas/trie_ip"
+)
+
+const (
+	UMBRAL_CAMBIO_PREDETERMINADO = 10.0
+	FORMATO_TEXTO                = "texto"
+	FORMATO_JSON                 = "json"
+)
+
+// Cambio en la cantidad de visitas de un recurso entre dos archivos o datasets
+type cambioRecurso struct {
+	recurso string
+	antes   int
+	despues int
+}
+
+// Resultado de comparar dos archivos de log o datasets, con todas las listas ya ordenadas
+type comparacionArchivos struct {
+	anterior         string
+	posterior        string
+	umbral           float64
+	soloAnterior     []uint32
+	soloPosterior    []uint32
+	recursos         []cambioRecurso
+	dosNuevos        []uint32
+	dosDesaparecidos []uint32
+}
+
+// Representacion JSON de la comparacion. Las listas vacias se muestran como [] y el cambio porcentual de un recurso
+// que no existia es null
+type comparacionJSON struct {
+	Anterior         string              `json:"anterior"`
+	Posterior        string              `json:"posterior"`
+	Umbral           float64             `json:"umbral"`
+	SoloAnterior     []string            `json:"ips_solo_anterior"`
+	SoloPosterior    []string            `json:"ips_solo_posterior"`
+	Recursos         []cambioRecursoJSON `json:"recursos"`
+	DoSNuevos        []string            `json:"dos_nuevos"`
+	DoSDesaparecidos []string            `json:"dos_desaparecidos"`
+}
+
+type cambioRecursoJSON struct {
+	Recurso          string   `json:"recurso"`
+	Antes            int      `json:"antes"`
+	Despues          int      `json:"despues"`
+	CambioPorcentual *float64 `json:"cambio_porcentual"`
+}
+
+// PRE: el registro debe de existir
+// POST: registra en el registro el comando para comparar dos archivos de log o datasets
+func registrarComandosComparacion(registro *Registro) {
+	registro.Registrar(Comando{
+		Nombre:        "comparar_archivos",
+		Parametros:    "<a> <b> [umbral] [texto|json]",
+		Descripcion:   "Compara dos logs sin cargarlos, o datasets si a o b son nombres de datasets: IPs de uno solo, recursos cuyas visitas cambiaron mas del umbral (10% por defecto) y sospechosos de DoS que aparecieron o desaparecieron",
+		MinParametros: 2,
+		MaxParametros: 4,
+		Ejecutar:      ejecutarCompararArchivos,
+	})
+}
+
+// PRE: el contexto debe de existir
+// POST: obtiene los dos datasets a comparar, sin modificar los del contexto, y muestra la comparacion en el formato
+// indicado
+func ejecutarCompararArchivos(ctx *Contexto, parametros []string) error {
+	umbral, formato, err := parsearOpcionesComparacion(parametro(parametros, 2), parametro(parametros, 3))
+	if err != nil {
+		return err
+	}
+	var datasets [2]*Dataset
+	for i, operando := range parametros[:2] {
+		if datasets[i], err = datasetAComparar(ctx, operando); err != nil {
+			return err
+		}
+	}
+	comparacion := compararArchivos(datasets[0], datasets[1], umbral)
+	if formato == FORMATO_JSON {
+		return mostrarComparacionJSON(ctx.Salida, comparacion)
+	}
+	mostrarComparacionTexto(ctx.Salida, comparacion, ctx.Redes)
+	return nil
+}
+
+// PRE:
+// POST: retorna el umbral de cambio porcentual y el formato de salida. Si se omiten se usan UMBRAL_CAMBIO_PREDETERMINADO
+// y FORMATO_TEXTO. Retorna un error si el umbral no es un porcentaje no negativo o el formato no es valido
+func parsearOpcionesComparacion(umbralStr, formato string) (float64, string, error) {
+	umbral := UMBRAL_CAMBIO_PREDETERMINADO
+	if umbralStr != "" {
+		var err error
+		umbral, err = strconv.ParseFloat(strings.TrimSuffix(umbralStr, "%"), 64)
+		if err != nil || umbral < 0 || math.IsInf(umbral, 0) || math.IsNaN(umbral) {
+			return 0, "", errorDetallado(ErrNInvalido, "%q no es un porcentaje no negativo", umbralStr)
+		}
+	}
+	switch formato {
+	case "":
+		return umbral, FORMATO_TEXTO, nil
+	case FORMATO_TEXTO, FORMATO_JSON:
+		return umbral, formato, nil
+	}
+	return 0, "", errorDetallado(ErrParametroInvalido, "formato desconocido %q", formato)
+}
+
+// PRE: el contexto debe de existir
+// POST: retorna el dataset del contexto con ese nombre o, si no hay ninguno, un dataset temporal con el archivo
+// analizado. Retorna un error si el dataset es aproximado, ya que no tiene los conteos exactos de los recursos, o si el
+// archivo no se puede leer
+func datasetAComparar(ctx *Contexto, operando string) (*Dataset, error) {
+	if d, ok := ctx.ObtenerDataset(operando); ok {
+		if d.Aproximado {
+			return nil, errorDetallado(ErrDatasetAproximado, "%s", operando)
+		}
+		return d, nil
+	}
+	return analizarArchivoAislado(ctx.Redes, operando)
+}
+
+// PRE: el trie de redes debe de existir
+// POST: analiza las lineas validas del archivo en un dataset propio, sin mostrar nada ni modificar el contexto, y lo
+// retorna. Si el archivo no se puede abrir o leer retorna el error correspondiente
+func analizarArchivoAislado(redes TDATRIEIP.TrieIP[string], archivo string) (*Dataset, error) {
+	file, err := AbrirArchivo(archivo)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	aislado := &Contexto{Dataset: crearDatasetVacio(archivo, false), Redes: redes, Salida: io.Discard}
+	var errLectura error
+	aislado.analizarLineas([]string{archivo}, filtrarValidas(lineasArchivo(file, &errLectura)))
+	if errLectura != nil {
+		return nil, errLectura
+	}
+	return aislado.Dataset, nil
+}
+
+// PRE: ambos datasets deben de existir y ser exactos
+// POST: compara los datasets tomando al primero como el anterior, sean de archivos sueltos o del contexto. Los recursos se incluyen si su cantidad de visitas
+// cambio estrictamente mas que el umbral, en porcentaje respecto del anterior
+func compararArchivos(anterior, posterior *Dataset, umbral float64) comparacionArchivos {
+	c := comparacionArchivos{anterior: anterior.Nombre, posterior: posterior.Nombre, umbral: umbral}
+	c.soloAnterior, c.soloPosterior = diferenciaIPs(anterior.Arbol.Todos(), posterior.Arbol.Todos())
+	c.dosDesaparecidos, c.dosNuevos = diferenciaIPs(sospechososDataset(anterior).Todos(), sospechososDataset(posterior).Todos())
+	c.recursos = cambiosRecursos(anterior.Recursos, posterior.Recursos, umbral)
+	return c
+}
+
+// PRE: el dataset debe de existir
+// POST: retorna las IPs sospechosas de DoS de todas las cargas del dataset, ordenadas, con su cantidad de rafagas
+func sospechososDataset(d *Dataset) TDADICC.DiccionarioOrdenado[uint32, int] {
+	sospechosos := TDADICC.CrearABB[uint32, int](CompararIPs)
+	for _, c := range d.cargas {
+		for _, inc := range c.incidentes {
+			ip := ipStringANumero(inc.ip)
+			rafagas := 0
+			if sospechosos.Pertenece(ip) {
+				rafagas = sospechosos.Obtener(ip)
+			}
+			sospechosos.Guardar(ip, rafagas+1)
+		}
+	}
+	return sospechosos
+}
+
+// PRE: ambos diccionarios de recursos deben de existir y el umbral debe de ser no negativo
+// POST: retorna los recursos de alguno de los diccionarios cuya cantidad de visitas cambio mas que el umbral, del que
+// mas cambio al que menos, y los empates por nombre. Los recursos nuevos se consideran un cambio infinito
+func cambiosRecursos(anteriores, posteriores TDADICC.Diccionario[string, int], umbral float64) []cambioRecurso {
+	var cambios []cambioRecurso
+	agregarSiSupera := func(cambio cambioRecurso) {
+		if math.Abs(cambio.porcentaje()) > umbral {
+			cambios = append(cambios, cambio)
+		}
+	}
+	for recurso, antes := range anteriores.Todos() {
+		despues := 0
+		if posteriores.Pertenece(recurso) {
+			despues = posteriores.Obtener(recurso)
+		}
+		agregarSiSupera(cambioRecurso{recurso: recurso, antes: antes, despues: despues})
+	}
+	for recurso, despues := range posteriores.Todos() {
+		if !anteriores.Pertenece(recurso) {
+			agregarSiSupera(cambioRecurso{recurso: recurso, despues: despues})
+		}
+	}
+	TDAORD.MergeSort(cambios, compararCambiosRecursos)
+	return cambios
+}
+
+// PRE:
+// POST: retorna el cambio porcentual de las visitas respecto de las anteriores, o +Inf si el recurso no existia
+func (c cambioRecurso) porcentaje() float64 {
+	if c.antes == 0 {
+		return math.Inf(1)
+	}
+	return float64(c.despues-c.antes) / float64(c.antes) * 100
+}
+
+// PRE:
+// POST: compara los cambios por la magnitud del cambio porcentual en orden descendente, y a igual magnitud por recurso
+func compararCambiosRecursos(c1, c2 cambioRecurso) int {
+	if comparacion := cmp.Compare(math.Abs(c2.porcentaje()), math.Abs(c1.porcentaje())); comparacion != 0 {
+		return comparacion
+	}
+	return cmp.Compare(c1.recurso, c2.recurso)
+}
+
+// PRE: la comparacion y el trie de redes deben de existir
+// POST: muestra la comparacion como texto, con las IPs etiquetadas con su red
+func mostrarComparacionTexto(salida io.Writer, c comparacionArchivos, redes TDATRIEIP.TrieIP[string]) {
+	mostrarIPs := func(titulo string, ips []uint32) {
+		fmt.Fprintf(salida, "%s: %d\n", titulo, len(ips))
+		for _, ip := range ips {
+			fmt.Fprintf(salida, "\t%s\n", etiquetarIP(redes, ip))
+		}
+	}
+	fmt.Fprintf(salida, "Comparacion de %s con %s:\n", c.anterior, c.posterior)
+	mostrarIPs("IPs solo en "+c.anterior, c.soloAnterior)
+	mostrarIPs("IPs solo en "+c.posterior, c.soloPosterior)
+	fmt.Fprintf(salida, "Recursos con cambios de mas del %g%%: %d\n", c.umbral, len(c.recursos))
+	for _, cambio := range c.recursos {
+		if cambio.antes == 0 {
+			fmt.Fprintf(salida, "\t%s: %d -> %d (nuevo)\n", cambio.recurso, cambio.antes, cambio.despues)
+		} else {
+			fmt.Fprintf(salida, "\t%s: %d -> %d (%+.1f%%)\n", cambio.recurso, cambio.antes, cambio.despues, cambio.porcentaje())
+		}
+	}
+	mostrarIPs("Sospechosos de DoS nuevos", c.dosNuevos)
+	mostrarIPs("Sospechosos de DoS desaparecidos", c.dosDesaparecidos)
+	fmt.Fprintln(salida, "OK")
+}
+
+// PRE: la comparacion debe de existir
+// POST: muestra la comparacion como un unico documento JSON, sin la linea OK, para que se pueda procesar directamente
+func mostrarComparacionJSON(salida io.Writer, c comparacionArchivos) error {
+	documento := comparacionJSON{
+		Anterior:         c.anterior,
+		Posterior:        c.posterior,
+		Umbral:           c.umbral,
+		SoloAnterior:     ipsAStrings(c.soloAnterior),
+		SoloPosterior:    ipsAStrings(c.soloPosterior),
+		Recursos:         make([]cambioRecursoJSON, len(c.recursos)),
+		DoSNuevos:        ipsAStrings(c.dosNuevos),
+		DoSDesaparecidos: ipsAStrings(c.dosDesaparecidos),
+	}
+	for i, cambio := range c.recursos {
+		documento.Recursos[i] = cambioRecursoJSON{Recurso: cambio.recurso, Antes: cambio.antes, Despues: cambio.despues}
+		if cambio.antes != 0 {
+			porcentaje := math.Round(cambio.porcentaje()*10) / 10
+			documento.Recursos[i].CambioPorcentual = &porcentaje
+		}
+	}
+	codificador := json.NewEncoder(salida)
+	codificador.SetIndent("", "  ")
+	return codificador.Encode(documento)
+}
+
+// PRE:
+// POST: retorna las IPs en formato string, en el mismo orden. Si no hay IPs retorna una lista vacia, no nil
+func ipsAStrings(ips []uint32) []string {
+	strs := make([]string, len(ips))
+	for i, ip := range ips {
+		strs[i] = ipAString(ip)
+	}
+	return strs
+}
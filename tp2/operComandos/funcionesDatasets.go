@@ -0,0 +1,225 @@
+package operComandos
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"iter"
+	TDADICC "tdas/diccionario"
+	TDAORD "tdas/ordenamiento"
+	TDATRIEIP "tdas/trie_ip"
+)
+
+const MAX_CAMBIOS_RANKING = 10
+
+// Cambio de posicion de un recurso en el ranking de mas visitados entre dos datasets
+type cambioRanking struct {
+	recurso string
+	antes   int
+	despues int
+}
+
+// PRE: el registro debe de existir
+// POST: registra en el registro los comandos para crear, seleccionar y comparar datasets
+func registrarComandosDatasets(registro *Registro) {
+	registro.Registrar(Comando{
+		Nombre:        "crear_dataset",
+		Parametros:    "<nombre> [aprox]",
+		Descripcion:   "Crea un dataset vacio y lo selecciona, para que los comandos siguientes se apliquen a el. Uno aprox solo estima los recursos mas visitados, con memoria acotada",
+		MinParametros: 1,
+		MaxParametros: 2,
+		Ejecutar:      ejecutarCrearDataset,
+	})
+	registro.Registrar(Comando{
+		Nombre:        "usar_dataset",
+		Parametros:    "<nombre>",
+		Descripcion:   "Selecciona el dataset al que se aplican los comandos siguientes",
+		MinParametros: 1,
+		MaxParametros: 1,
+		Ejecutar:      ejecutarUsarDataset,
+	})
+	registro.Registrar(Comando{
+		Nombre:        "ver_datasets",
+		Descripcion:   "Lista los datasets con su cantidad de cargas, marcando el actual",
+		MinParametros: 0,
+		MaxParametros: 0,
+		Ejecutar:      ejecutarVerDatasets,
+	})
+	registro.Registrar(Comando{
+		Nombre:        "comparar_datasets",
+		Parametros:    "<A> <B>",
+		Descripcion:   "Muestra los visitantes de B que no estaban en A, los de A que no estan en B y los recursos cuyo ranking mas cambio",
+		MinParametros: 2,
+		MaxParametros: 2,
+		Ejecutar:      ejecutarCompararDatasets,
+	})
+}
+
+// PRE: el contexto debe de existir
+// POST: crea el dataset, exacto o aproximado segun el modo, y lo hace el actual. Si ya existe o el modo no es valido
+// retorna un error
+func ejecutarCrearDataset(ctx *Contexto, parametros []string) error {
+	modo := parametro(parametros, 1)
+	if modo != "" && modo != MODO_APROXIMADO {
+		return errorDetallado(ErrParametroInvalido, "modo desconocido %q", modo)
+	}
+	if !ctx.CrearDataset(parametros[0], modo == MODO_APROXIMADO) {
+		return errorDetallado(ErrDatasetExistente, "%s", parametros[0])
+	}
+	fmt.Fprintf(ctx.Salida, "Dataset actual: %s\n", ctx.Nombre)
+	fmt.Fprintln(ctx.Salida, "OK")
+	return nil
+}
+
+// PRE: el contexto debe de existir
+// POST: hace actual al dataset. Si no existe retorna un error
+func ejecutarUsarDataset(ctx *Contexto, parametros []string) error {
+	if !ctx.UsarDataset(parametros[0]) {
+		return errorDetallado(ErrDatasetInexistente, "%s", parametros[0])
+	}
+	fmt.Fprintf(ctx.Salida, "Dataset actual: %s\n", ctx.Nombre)
+	fmt.Fprintln(ctx.Salida, "OK")
+	return nil
+}
+
+// PRE: el contexto debe de existir
+// POST: muestra los datasets en el orden en que se crearon
+func ejecutarVerDatasets(ctx *Contexto, _ []string) error {
+	fmt.Fprintln(ctx.Salida, "Datasets:")
+	for d := range ctx.Datasets() {
+		marcas := ""
+		if d.Aproximado {
+			marcas += " (aproximado)"
+		}
+		if d == ctx.Dataset {
+			marcas += " (actual)"
+		}
+		fmt.Fprintf(ctx.Salida, "\t%s%s - %d cargas\n", d.Nombre, marcas, d.CantidadCargas())
+	}
+	fmt.Fprintln(ctx.Salida, "OK")
+	return nil
+}
+
+// PRE: el contexto debe de existir
+// POST: compara los dos datasets tomando al primero como el anterior. Si alguno no existe retorna un error
+func ejecutarCompararDatasets(ctx *Contexto, parametros []string) error {
+	var datasets [2]*Dataset
+	for i, nombre := range parametros {
+		d, ok := ctx.ObtenerDataset(nombre)
+		if !ok {
+			return errorDetallado(ErrDatasetInexistente, "%s", nombre)
+		}
+		datasets[i] = d
+	}
+	compararDatasets(ctx.Salida, datasets[0], datasets[1], ctx.Redes)
+	return nil
+}
+
+// PRE: ambos datasets y el trie de redes deben de existir
+// POST: muestra las IPs que solo visitaron el dataset posterior, las que solo visitaron el anterior y los recursos
+// presentes en ambos cuya posicion en el ranking de mas visitados mas cambio. El ranking de un dataset aproximado solo
+// incluye los recursos monitoreados por su TopK, con sus conteos estimados
+func compararDatasets(salida io.Writer, anterior, posterior *Dataset, redes TDATRIEIP.TrieIP[string]) {
+	desaparecidos, nuevos := diferenciaIPs(anterior.Arbol.Todos(), posterior.Arbol.Todos())
+	fmt.Fprintf(salida, "Comparacion de %s con %s:\n", anterior.Nombre, posterior.Nombre)
+	fmt.Fprintf(salida, "Visitantes nuevos: %d\n", len(nuevos))
+	for _, ip := range nuevos {
+		fmt.Fprintf(salida, "\t%s\n", etiquetarIP(redes, ip))
+	}
+	fmt.Fprintf(salida, "Visitantes desaparecidos: %d\n", len(desaparecidos))
+	for _, ip := range desaparecidos {
+		fmt.Fprintf(salida, "\t%s\n", etiquetarIP(redes, ip))
+	}
+	fmt.Fprintln(salida, "Cambios de ranking:")
+	for _, cambio := range cambiosRanking(anterior.conteosRecursos(), posterior.conteosRecursos(), MAX_CAMBIOS_RANKING) {
+		fmt.Fprintf(salida, "\t%s: %d -> %d (%+d)\n", cambio.recurso, cambio.antes, cambio.despues, cambio.antes-cambio.despues)
+	}
+	fmt.Fprintln(salida, "OK")
+}
+
+// PRE: ambas secuencias deben de recorrer las IPs en orden ascendente
+// POST: recorre ambas secuencias a la vez y retorna, en orden, las IPs que solo estan en la primera y las que solo
+// estan en la segunda
+func diferenciaIPs(a, b iter.Seq2[uint32, int]) ([]uint32, []uint32) {
+	siguienteA, cortarA := iter.Pull2(a)
+	defer cortarA()
+	siguienteB, cortarB := iter.Pull2(b)
+	defer cortarB()
+
+	var soloA, soloB []uint32
+	ipA, _, hayA := siguienteA()
+	ipB, _, hayB := siguienteB()
+	for hayA || hayB {
+		switch {
+		case !hayB || (hayA && ipA < ipB):
+			soloA = append(soloA, ipA)
+			ipA, _, hayA = siguienteA()
+		case !hayA || ipB < ipA:
+			soloB = append(soloB, ipB)
+			ipB, _, hayB = siguienteB()
+		default:
+			ipA, _, hayA = siguienteA()
+			ipB, _, hayB = siguienteB()
+		}
+	}
+	return soloA, soloB
+}
+
+// PRE: recursos debe de recorrer los recursos con su conteo de visitas
+// POST: retorna la posicion de cada recurso en el ranking de mas visitados. Los recursos con el mismo conteo
+// comparten la posicion, para que los empates no se cuenten como cambios
+func rankingRecursos(recursos iter.Seq2[string, int]) TDADICC.Diccionario[string, int] {
+	var ordenados []recursoConConteo
+	for recurso, conteo := range recursos {
+		ordenados = append(ordenados, recursoConConteo{recurso: recurso, conteo: conteo})
+	}
+	TDAORD.MergeSort(ordenados, compararMasVisitados)
+
+	ranking := TDADICC.CrearHash[string, int]()
+	posicion := 0
+	for i, r := range ordenados {
+		if i == 0 || r.conteo != ordenados[i-1].conteo {
+			posicion = i + 1
+		}
+		ranking.Guardar(r.recurso, posicion)
+	}
+	return ranking
+}
+
+// PRE: ambas secuencias deben de recorrer los recursos con su conteo de visitas. n debe de ser mayor o igual a 0
+// POST: retorna a lo sumo n recursos presentes en ambas secuencias cuya posicion en el ranking cambio, del que mas
+// cambio al que menos, y los empates por nombre
+func cambiosRanking(anteriores, posteriores iter.Seq2[string, int], n int) []cambioRanking {
+	rankingAnterior := rankingRecursos(anteriores)
+	rankingPosterior := rankingRecursos(posteriores)
+
+	var cambios []cambioRanking
+	for recurso, antes := range rankingAnterior.Todos() {
+		if !rankingPosterior.Pertenece(recurso) {
+			continue
+		}
+		if despues := rankingPosterior.Obtener(recurso); despues != antes {
+			cambios = append(cambios, cambioRanking{recurso: recurso, antes: antes, despues: despues})
+		}
+	}
+	TDAORD.MergeSort(cambios, compararCambiosRanking)
+	return cambios[:min(n, len(cambios))]
+}
+
+// PRE:
+// POST: compara los cambios por la magnitud del cambio de posicion en orden descendente, y a igual magnitud por recurso
+func compararCambiosRanking(c1, c2 cambioRanking) int {
+	if comparacion := cmp.Compare(c2.magnitud(), c1.magnitud()); comparacion != 0 {
+		return comparacion
+	}
+	return cmp.Compare(c1.recurso, c2.recurso)
+}
+
+// PRE:
+// POST: retorna cuantas posiciones se movio el recurso en el ranking, sin importar la direccion
+func (c cambioRanking) magnitud() int {
+	if c.antes > c.despues {
+		return c.antes - c.despues
+	}
+	return c.despues - c.antes
+}
@@ -2,13 +2,79 @@ package operComandos
 
 import (
 	"bufio"
+	"encoding/binary"
 	"fmt"
+	"iter"
 	"net"
 	"os"
 	"strings"
+	TDABLOOM "tdas/bloom"
 	TDADICC "tdas/diccionario"
+	TDAORD "tdas/ordenamiento"
+	TDATRIEIP "tdas/trie_ip"
 )
 
+const COMENTARIO_REDES = "#"
+
+type redConEtiqueta struct {
+	prefijo  uint32
+	largo    int
+	etiqueta string
+}
+
+// Filtro de Bloom con contadores de las IPs vistas, que arranca chico y duplica su capacidad cuando se llena para
+// mantener la tasa de falsos positivos, en lugar de reservar de entrada la memoria de la cantidad maxima de IPs.
+// Cada IP se agrega una sola vez, mientras este en el ABB de visitantes
+type filtroIPs struct {
+	filtro    TDABLOOM.FiltroBloomContador
+	capacidad int
+	cantidad  int
+}
+
+// PRE: la capacidad debe de ser mayor a 0
+// POST: crea un filtro de IPs vacio para la capacidad indicada
+func crearFiltroIPs(capacidad int) *filtroIPs {
+	return &filtroIPs{filtro: TDABLOOM.CrearFiltroBloomContador(capacidad, TASA_FP_IPS_VISTAS), capacidad: capacidad}
+}
+
+// PRE: el filtro debe de existir
+// POST: retorna false si la IP seguro no fue agregada, y true si probablemente lo fue
+func (f *filtroIPs) puedePertenecer(ip uint32) bool {
+	return f.filtro.PuedePertenecer(claveIP(ip))
+}
+
+// PRE: el filtro debe de existir, las IPs de nuevas no deben de estar agregadas y anteriores debe de recorrer todas
+// las IPs ya agregadas
+// POST: agrega las IPs nuevas. Si no entran en la capacidad, la duplica las veces necesarias y rearma el filtro una
+// sola vez a partir de anteriores y nuevas. Rearmarlo cuesta O(IPs agregadas), pero al duplicar la capacidad pasa
+// O(log n) veces para n IPs, por lo que el costo amortizado por IP agregada es O(1)
+func (f *filtroIPs) agregar(nuevas []uint32, anteriores iter.Seq[uint32]) {
+	f.cantidad += len(nuevas)
+	if f.cantidad <= f.capacidad {
+		for _, ip := range nuevas {
+			f.filtro.Agregar(claveIP(ip))
+		}
+		return
+	}
+	for f.cantidad > f.capacidad {
+		f.capacidad *= FACTOR_EXPANSION
+	}
+	f.filtro = TDABLOOM.CrearFiltroBloomContador(f.capacidad, TASA_FP_IPS_VISTAS)
+	for ip := range anteriores {
+		f.filtro.Agregar(claveIP(ip))
+	}
+	for _, ip := range nuevas {
+		f.filtro.Agregar(claveIP(ip))
+	}
+}
+
+// PRE: el filtro debe de existir y la IP debe de haber sido agregada
+// POST: quita la IP del filtro
+func (f *filtroIPs) borrar(ip uint32) {
+	f.filtro.Borrar(claveIP(ip))
+	f.cantidad--
+}
+
 // PRE: ipStr debe ser una dirección IP válida en formato string
 // POST: convierte una direccion IP de tipo string a un numero comparable (uint32)
 func ipStringANumero(ipStr string) uint32 {
@@ -19,6 +85,34 @@ func ipStringANumero(ipStr string) uint32 {
 	return uint32(ip[0])<<24 + uint32(ip[1])<<16 + uint32(ip[2])<<8 + uint32(ip[3])
 }
 
+// PRE:
+// POST: convierte la IP ingresada por el usuario a su numero comparable. Si no es una IPv4 valida retorna un error de IP invalida
+// Es la validacion que comparten todos los comandos que reciben IPs como parametro
+func parsearIP(ipStr string) (uint32, error) {
+	if net.ParseIP(ipStr).To4() == nil {
+		return 0, errorDetallado(ErrIPInvalida, "%q no es una IPv4 valida", ipStr)
+	}
+	return ipStringANumero(ipStr), nil
+}
+
+// PRE:
+// POST: convierte los limites de un rango de IPs ingresados por el usuario, validando ambos. Si el rango esta invertido
+// intercambia los limites, de forma que siempre se retorna desde <= hasta
+func parsearRangoIPs(desdeStr, hastaStr string) (uint32, uint32, error) {
+	desde, err := parsearIP(desdeStr)
+	if err != nil {
+		return 0, 0, err
+	}
+	hasta, err := parsearIP(hastaStr)
+	if err != nil {
+		return 0, 0, err
+	}
+	if hasta < desde {
+		desde, hasta = hasta, desde
+	}
+	return desde, hasta, nil
+}
+
 // PRE: ip debe ser una dirección IP válida representada como uint32.
 // POST: convierte un numero IP a formato de tipo string
 func ipAString(ip uint32) string {
@@ -36,16 +130,106 @@ func CompararIPs(ip1, ip2 uint32) int {
 	return 0
 }
 
-// PRE: el arbol debe de existir
-// POST: ordena las IPs en un ABB
-func actualizarIPS(arbol TDADICC.DiccionarioOrdenado[uint32, bool], file *os.File) {
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		linea := scanner.Text()
+// PRE: ip debe ser una dirección IP válida representada como uint32.
+// POST: retorna la clave de la IP en el filtro de IPs vistas: sus 4 bytes, que es mas rapido de armar que el texto de la IP
+func claveIP(ip uint32) string {
+	return string(binary.BigEndian.AppendUint32(nil, ip))
+}
+
+// PRE: el arbol y el filtro de IPs vistas deben de existir
+// POST: ordena las IPs en un ABB, contando en cuantas cargas aparece cada una, y retorna las IPs de las lineas sin repetir y ordenadas.
+// Las lineas solo pasan por el hash de la carga; despues cada IP distinta se busca en el ABB una sola vez, y solo si el
+// filtro dice que puede estar, asi que las IPs nuevas no se buscan. Las nuevas se unen al ABB en tiempo lineal, lo que
+// ademas lo deja balanceado
+func actualizarIPS(arbol TDADICC.DiccionarioOrdenado[uint32, int], vistos *filtroIPs, lineas iter.Seq[string]) []uint32 {
+	vistas := TDADICC.CrearHash[uint32, bool]()
+	var ips []uint32
+	for linea := range lineas {
 		parte := strings.Split(linea, "\t")
 		ip := ipStringANumero(parte[0])
-		if !arbol.Pertenece(ip) {
-			arbol.Guardar(ip, true)
+		if !vistas.Pertenece(ip) {
+			vistas.Guardar(ip, true)
+			ips = append(ips, ip)
 		}
 	}
+	TDAORD.RadixSort(ips)
+	var nuevas []uint32
+	for _, ip := range ips {
+		// Si el filtro nunca vio la IP tampoco esta en el ABB, asi que no hace falta buscarla
+		if vistos.puedePertenecer(ip) && arbol.Pertenece(ip) {
+			arbol.Guardar(ip, arbol.Obtener(ip)+1)
+		} else {
+			nuevas = append(nuevas, ip)
+		}
+	}
+	// El filtro se actualiza antes de unir las nuevas al ABB, que todavia tiene solo las IPs ya agregadas
+	vistos.agregar(nuevas, func(yield func(uint32) bool) {
+		for ip := range arbol.Todos() {
+			if !yield(ip) {
+				return
+			}
+		}
+	})
+	cargas := make([]int, len(nuevas))
+	for i := range cargas {
+		cargas[i] = 1
+	}
+	arbol.Unir(TDADICC.CrearABBDesdeOrdenado(nuevas, cargas, CompararIPs))
+	return ips
+}
+
+// PRE: el arbol y el filtro deben de incluir las IPs de una carga
+// POST: descuenta la carga de cada IP, borrando del ABB y del filtro las que ya no aparecen en ninguna
+func quitarIPs(arbol TDADICC.DiccionarioOrdenado[uint32, int], vistos *filtroIPs, ips []uint32) {
+	for _, ip := range ips {
+		if cargas := arbol.Obtener(ip); cargas > 1 {
+			arbol.Guardar(ip, cargas-1)
+		} else {
+			arbol.Borrar(ip)
+			vistos.borrar(ip)
+		}
+	}
+}
+
+// PRE: cidr debe de ser una red en notacion CIDR, como 10.0.0.0/8
+// POST: retorna el prefijo y el largo de la red, y false si no es una red IPv4 valida
+func parsearRed(cidr string) (uint32, int, bool) {
+	_, red, err := net.ParseCIDR(cidr)
+	if err != nil || red.IP.To4() == nil {
+		return 0, 0, false
+	}
+	largo, _ := red.Mask.Size()
+	return ipStringANumero(red.IP.String()), largo, true
+}
+
+// PRE: el archivo debe de estar abierto en modo lectura, con una red por linea seguida de su etiqueta. Se ignoran las lineas vacias y las que empiezan con '#'
+// POST: guarda las redes en el trie y retorna nil. Si alguna linea es invalida no guarda ninguna red y retorna un error de archivo invalido con el numero de linea
+func cargarRedes(redes TDATRIEIP.TrieIP[string], file *os.File) error {
+	var leidas []redConEtiqueta
+	scanner := bufio.NewScanner(file)
+	for numero := 1; scanner.Scan(); numero++ {
+		campos := strings.Fields(scanner.Text())
+		if len(campos) == 0 || strings.HasPrefix(campos[0], COMENTARIO_REDES) {
+			continue
+		}
+		prefijo, largo, ok := parsearRed(campos[0])
+		if !ok || len(campos) < 2 {
+			return errorDetallado(ErrArchivoInvalido, "%s linea %d: se esperaba una red CIDR seguida de su etiqueta", file.Name(), numero)
+		}
+		leidas = append(leidas, redConEtiqueta{prefijo, largo, strings.Join(campos[1:], " ")})
+	}
+	for _, red := range leidas {
+		redes.Guardar(red.prefijo, red.largo, red.etiqueta)
+	}
+	return nil
+}
+
+// PRE: el trie de redes debe de existir
+// POST: retorna la IP en formato string, seguida de la etiqueta de la red mas especifica que la contiene si la hay
+func etiquetarIP(redes TDATRIEIP.TrieIP[string], ip uint32) string {
+	_, _, etiqueta, encontrado := redes.BuscarMasEspecifico(ip)
+	if !encontrado {
+		return ipAString(ip)
+	}
+	return fmt.Sprintf("%s (%s)", ipAString(ip), etiqueta)
 }
@@ -0,0 +1,59 @@
+package operComandos
+
+import (
+	"fmt"
+	"iter"
+	TDADICC "tdas/diccionario"
+	"testing"
+)
+
+const (
+	IPS_CARGA_ANTERIOR = 10000
+	IPS_CARGA_NUEVA    = 10000
+	REPETICIONES_IP    = 4
+)
+
+// ABB de IPs que cuenta cuantas veces se busca una IP en el
+type abbContado struct {
+	TDADICC.DiccionarioOrdenado[uint32, int]
+	busquedas int
+}
+
+func (a *abbContado) Pertenece(ip uint32) bool {
+	a.busquedas++
+	return a.DiccionarioOrdenado.Pertenece(ip)
+}
+
+// PRE:
+// POST: retorna lineas de log con cada una de las IPs desde..hasta-1 repetida REPETICIONES_IP veces
+func lineasConIPs(desde, hasta uint32) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for i := 0; i < REPETICIONES_IP; i++ {
+			for ip := desde; ip < hasta; ip++ {
+				if !yield(fmt.Sprintf("%s\t2015-05-17T10:05:00+00:00\tGET\t/", ipAString(ip))) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Carga un log con la mitad de sus IPs ya vistas en una carga anterior y la otra mitad nuevas. Cada IP distinta se
+// busca en el ABB a lo sumo una vez, y el filtro evita buscar las nuevas: las busquedas deberian ser cerca de la
+// mitad de las IPs distintas, mientras que sin el filtro serian todas
+func BenchmarkActualizarIPSConFiltro(b *testing.B) {
+	desde := uint32(IPS_CARGA_ANTERIOR / 2)
+	busquedas := 0
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		arbol := &abbContado{DiccionarioOrdenado: TDADICC.CrearABB[uint32, int](CompararIPs)}
+		vistos := crearFiltroIPs(CAPACIDAD_IPS_VISTAS)
+		actualizarIPS(arbol, vistos, lineasConIPs(0, IPS_CARGA_ANTERIOR))
+		arbol.busquedas = 0
+		b.StartTimer()
+		actualizarIPS(arbol, vistos, lineasConIPs(desde, desde+IPS_CARGA_NUEVA))
+		busquedas += arbol.busquedas
+	}
+	b.ReportMetric(float64(busquedas)/float64(b.N), "busquedas_abb/op")
+	b.ReportMetric(IPS_CARGA_NUEVA, "ips_distintas/op")
+}
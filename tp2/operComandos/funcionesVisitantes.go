@@ -0,0 +1,304 @@
+package operComandos
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"strings"
+	TDADICC "tdas/diccionario"
+	TDAHLL "tdas/hyperloglog"
+	TDAORD "tdas/ordenamiento"
+	TDATOPK "tdas/top_k"
+	"time"
+)
+
+// Visitantes unicos aproximados en total, por hora y por recurso, en memoria constante sin importar cuantas IPs
+// distintas haya. Para que tampoco dependa de la cantidad de recursos distintos, solo se guardan los visitantes de a lo
+// sumo MAX_RECURSOS_HLL recursos
+type VisitantesAprox struct {
+	total TDAHLL.HyperLogLog
+	// Visitantes de cada hora, por el inicio de la hora
+	porHora    TDADICC.DiccionarioOrdenado[time.Time, TDAHLL.HyperLogLog]
+	porRecurso TDADICC.Diccionario[string, TDAHLL.HyperLogLog]
+}
+
+// Visitantes aproximados tal como se guardan en un archivo, con cada HyperLogLog serializado
+type visitantesSerializados struct {
+	Total      []byte
+	PorHora    []hllHoraSerializado
+	PorRecurso []hllRecursoSerializado
+}
+
+type hllHoraSerializado struct {
+	Hora time.Time
+	HLL  []byte
+}
+
+type hllRecursoSerializado struct {
+	Recurso string
+	HLL     []byte
+}
+
+// PRE: el registro debe de existir
+// POST: registra en el registro los comandos para consultar, guardar y cargar los visitantes aproximados
+func registrarComandosVisitantes(registro *Registro) {
+	registro.Registrar(Comando{
+		Nombre:        "contar_visitantes_por_hora",
+		Parametros:    "<desde> [hasta]",
+		Descripcion:   "Estima la cantidad de IPs distintas de cada hora que se solapa con el instante o el rango de tiempo, y la del rango completo",
+		MinParametros: 1,
+		MaxParametros: 2,
+		RequiereDatos: true,
+		Ejecutar:      ejecutarContarVisitantesPorHora,
+	})
+	registro.Registrar(Comando{
+		Nombre:        "guardar_visitantes_aprox",
+		Parametros:    "<file>",
+		Descripcion:   "Guarda en el archivo los HyperLogLog de visitantes del dataset actual, para unirlos luego con cargar_visitantes_aprox",
+		MinParametros: 1,
+		MaxParametros: 1,
+		RequiereDatos: true,
+		Ejecutar:      ejecutarGuardarVisitantesAprox,
+	})
+	registro.Registrar(Comando{
+		Nombre:        "cargar_visitantes_aprox",
+		Parametros:    "<file>",
+		Descripcion:   "Une a los visitantes aproximados del dataset actual los guardados en el archivo, como una carga mas que se puede quitar",
+		MinParametros: 1,
+		MaxParametros: 1,
+		Ejecutar:      ejecutarCargarVisitantesAprox,
+	})
+}
+
+// PRE: el contexto debe de existir
+// POST: muestra los visitantes aproximados de cada hora del rango indicado y los del rango completo
+func ejecutarContarVisitantesPorHora(ctx *Contexto, parametros []string) error {
+	desde, hasta, err := parsearRangoTiempo(parametros[0], parametro(parametros, 1))
+	if err != nil {
+		return err
+	}
+	contarVisitantesPorHora(ctx.Salida, ctx.VisitantesAprox, desde, hasta)
+	return nil
+}
+
+// PRE: el contexto debe de existir
+// POST: guarda los visitantes aproximados del dataset actual en el archivo, reemplazandolo si existe
+func ejecutarGuardarVisitantesAprox(ctx *Contexto, parametros []string) error {
+	datos, err := ctx.VisitantesAprox.serializar()
+	if err != nil {
+		return errorDetallado(ErrArchivoInvalido, "%s: %v", parametros[0], err)
+	}
+	if err := os.WriteFile(parametros[0], datos, 0o644); err != nil {
+		return errorAbrirArchivo(parametros[0], err)
+	}
+	fmt.Fprintln(ctx.Salida, "OK")
+	return nil
+}
+
+// PRE: el contexto debe de existir
+// POST: agrega al dataset actual una carga con los visitantes aproximados guardados en el archivo. Si el archivo no
+// se puede leer o no fue generado por guardar_visitantes_aprox retorna un error y no carga nada
+func ejecutarCargarVisitantesAprox(ctx *Contexto, parametros []string) error {
+	datos, err := os.ReadFile(parametros[0])
+	if err != nil {
+		return errorAbrirArchivo(parametros[0], err)
+	}
+	visitantes, err := cargarVisitantesAprox(datos)
+	if err != nil {
+		return errorDetallado(ErrArchivoInvalido, "%s: %v", parametros[0], err)
+	}
+	ctx.agregarCargaVisitantes(parametros[0], visitantes)
+	fmt.Fprintln(ctx.Salida, "OK")
+	return nil
+}
+
+// PRE:
+// POST: crea los HyperLogLog vacios para estimar visitantes unicos
+func CrearVisitantesAprox() *VisitantesAprox {
+	return &VisitantesAprox{
+		total:      TDAHLL.CrearHyperLogLog(PRECISION_HLL_TOTAL),
+		porHora:    TDADICC.CrearABB[time.Time, TDAHLL.HyperLogLog](time.Time.Compare),
+		porRecurso: TDADICC.CrearHash[string, TDAHLL.HyperLogLog](),
+	}
+}
+
+// PRE: visitantesAprox debe de existir
+// POST: registra la visita de la IP en el total y en el HyperLogLog de la hora del tiempo indicado
+func (v *VisitantesAprox) agregar(ip string, tiempo time.Time) {
+	v.total.Agregar(ip)
+	hora := tiempo.Truncate(time.Hour)
+	if !v.porHora.Pertenece(hora) {
+		v.porHora.Guardar(hora, TDAHLL.CrearHyperLogLog(PRECISION_HLL_HORA))
+	}
+	v.porHora.Obtener(hora).Agregar(ip)
+}
+
+// PRE: visitantesAprox y seguidos deben de existir y las lineas deben de ser validas
+// POST: registra en el HyperLogLog de cada recurso seguido las IPs de las lineas que lo solicitaron. Las lineas de
+// los demas recursos se ignoran
+func (v *VisitantesAprox) agregarRecursos(lineas iter.Seq[string], seguidos TDADICC.Diccionario[string, bool]) {
+	for linea := range lineas {
+		campo := strings.Split(linea, "\t")
+		if !seguidos.Pertenece(campo[3]) {
+			continue
+		}
+		if !v.porRecurso.Pertenece(campo[3]) {
+			v.porRecurso.Guardar(campo[3], TDAHLL.CrearHyperLogLog(PRECISION_HLL_RECURSO))
+		}
+		v.porRecurso.Obtener(campo[3]).Agregar(campo[0])
+	}
+}
+
+// PRE: visitantesAprox y otro deben de existir
+// POST: incorpora a visitantesAprox los visitantes de otro, como si se hubieran agregado a el, y si quedan mas de
+// MAX_RECURSOS_HLL recursos conserva solo los de mas visitantes. otro no se modifica
+func (v *VisitantesAprox) unir(otro *VisitantesAprox) {
+	v.total.Unir(otro.total)
+	for hora, hll := range otro.porHora.Todos() {
+		if !v.porHora.Pertenece(hora) {
+			v.porHora.Guardar(hora, TDAHLL.CrearHyperLogLog(PRECISION_HLL_HORA))
+		}
+		v.porHora.Obtener(hora).Unir(hll)
+	}
+	for recurso, hll := range otro.porRecurso.Todos() {
+		if !v.porRecurso.Pertenece(recurso) {
+			v.porRecurso.Guardar(recurso, TDAHLL.CrearHyperLogLog(PRECISION_HLL_RECURSO))
+		}
+		v.porRecurso.Obtener(recurso).Unir(hll)
+	}
+	v.acotarRecursos()
+}
+
+// PRE: visitantesAprox debe de existir
+// POST: si hay mas de MAX_RECURSOS_HLL recursos, descarta los HyperLogLog de los que tienen menos visitantes
+// estimados, y a igual estimacion los ultimos por nombre
+func (v *VisitantesAprox) acotarRecursos() {
+	if v.porRecurso.Cantidad() <= MAX_RECURSOS_HLL {
+		return
+	}
+	estimados := make([]recursoConConteo, 0, v.porRecurso.Cantidad())
+	for recurso, hll := range v.porRecurso.Todos() {
+		estimados = append(estimados, recursoConConteo{recurso: recurso, conteo: int(hll.Estimar())})
+	}
+	TDAORD.MergeSort(estimados, compararMasVisitados)
+	for _, descartado := range estimados[MAX_RECURSOS_HLL:] {
+		v.porRecurso.Borrar(descartado.recurso)
+	}
+}
+
+// PRE: visitantesAprox debe de existir
+// POST: retorna los visitantes unicos aproximados del recurso y true, o false si no se guardan sus visitantes
+func (v *VisitantesAprox) estimarRecurso(recurso string) (uint64, bool) {
+	if !v.porRecurso.Pertenece(recurso) {
+		return 0, false
+	}
+	return v.porRecurso.Obtener(recurso).Estimar(), true
+}
+
+// PRE: el TopK debe de existir
+// POST: retorna los MAX_RECURSOS_HLL recursos con mayor conteo estimado en el TopK, cuyos visitantes se guardan
+func recursosSeguidos(topK TDATOPK.TopK[string]) TDADICC.Diccionario[string, bool] {
+	seguidos := TDADICC.CrearHash[string, bool]()
+	topK.IterarMayores(MAX_RECURSOS_HLL, func(recurso string, _, _ int) bool {
+		seguidos.Guardar(recurso, true)
+		return true
+	})
+	return seguidos
+}
+
+// PRE: visitantesAprox debe de existir
+// POST: retorna los visitantes aproximados serializados, para volver a cargarlos con cargarVisitantesAprox
+func (v *VisitantesAprox) serializar() ([]byte, error) {
+	serializados := visitantesSerializados{Total: v.total.Serializar()}
+	for hora, hll := range v.porHora.Todos() {
+		serializados.PorHora = append(serializados.PorHora, hllHoraSerializado{Hora: hora, HLL: hll.Serializar()})
+	}
+	for recurso, hll := range v.porRecurso.Todos() {
+		serializados.PorRecurso = append(serializados.PorRecurso, hllRecursoSerializado{Recurso: recurso, HLL: hll.Serializar()})
+	}
+	var buffer bytes.Buffer
+	if err := gob.NewEncoder(&buffer).Encode(serializados); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// PRE: datos debe de haber sido generado por serializar
+// POST: retorna los visitantes aproximados serializados en datos, o un error si los datos no son validos o algun
+// HyperLogLog no tiene la precision que se usa para su tipo de estimacion
+func cargarVisitantesAprox(datos []byte) (*VisitantesAprox, error) {
+	var serializados visitantesSerializados
+	if err := gob.NewDecoder(bytes.NewReader(datos)).Decode(&serializados); err != nil {
+		return nil, errors.New("no fue generado por guardar_visitantes_aprox")
+	}
+	v := &VisitantesAprox{
+		porHora:    TDADICC.CrearABB[time.Time, TDAHLL.HyperLogLog](time.Time.Compare),
+		porRecurso: TDADICC.CrearHash[string, TDAHLL.HyperLogLog](),
+	}
+	var err error
+	if v.total, err = cargarHLL(serializados.Total, PRECISION_HLL_TOTAL); err != nil {
+		return nil, err
+	}
+	for _, s := range serializados.PorHora {
+		hll, err := cargarHLL(s.HLL, PRECISION_HLL_HORA)
+		if err != nil {
+			return nil, err
+		}
+		v.porHora.Guardar(s.Hora, hll)
+	}
+	for _, s := range serializados.PorRecurso {
+		hll, err := cargarHLL(s.HLL, PRECISION_HLL_RECURSO)
+		if err != nil {
+			return nil, err
+		}
+		v.porRecurso.Guardar(s.Recurso, hll)
+	}
+	v.acotarRecursos()
+	return v, nil
+}
+
+// PRE:
+// POST: retorna el HyperLogLog serializado en datos, o un error si no es valido o no tiene la precision indicada
+func cargarHLL(datos []byte, precision int) (TDAHLL.HyperLogLog, error) {
+	hll, err := TDAHLL.CargarHyperLogLog(datos)
+	if err != nil {
+		return nil, err
+	}
+	if hll.Precision() != precision {
+		return nil, fmt.Errorf("precision %d en lugar de %d", hll.Precision(), precision)
+	}
+	return hll, nil
+}
+
+// PRE: deben de existir los HyperLogLog de visitantes con la información inicializada.
+// POST: muestra la cantidad aproximada de visitantes unicos de todos los logs, o solo del recurso indicado si no es
+// vacio. Si no se guardan los visitantes del recurso lo indica en lugar de estimarlos
+func contarVisitantesAprox(salida io.Writer, visitantesAprox *VisitantesAprox, recurso string) {
+	if recurso == "" {
+		fmt.Fprintf(salida, "Visitantes unicos (aproximado): %d\n", visitantesAprox.total.Estimar())
+	} else if estimacion, ok := visitantesAprox.estimarRecurso(recurso); ok {
+		fmt.Fprintf(salida, "Visitantes unicos de %s (aproximado): %d\n", recurso, estimacion)
+	} else {
+		fmt.Fprintf(salida, "Visitantes unicos de %s: sin estimacion, no esta entre los %d recursos seguidos\n", recurso, MAX_RECURSOS_HLL)
+	}
+	fmt.Fprintln(salida, "OK")
+}
+
+// PRE: deben de existir los HyperLogLog de visitantes. desde debe de ser menor o igual a hasta
+// POST: muestra, en orden, la cantidad aproximada de visitantes unicos de cada hora que se solapa con [desde, hasta] y
+// la de todas esas horas juntas, uniendo sus HyperLogLog
+func contarVisitantesPorHora(salida io.Writer, visitantesAprox *VisitantesAprox, desde, hasta time.Time) {
+	rango := TDAHLL.CrearHyperLogLog(PRECISION_HLL_HORA)
+	primera := desde.Truncate(time.Hour)
+	fmt.Fprintln(salida, "Visitantes unicos por hora (aproximado):")
+	for hora, hll := range visitantesAprox.porHora.TodosRango(&primera, &hasta) {
+		fmt.Fprintf(salida, "\t%s - %d\n", hora.Format(LAYOUT), hll.Estimar())
+		rango.Unir(hll)
+	}
+	fmt.Fprintf(salida, "Visitantes unicos del rango (aproximado): %d\n", rango.Estimar())
+	fmt.Fprintln(salida, "OK")
+}
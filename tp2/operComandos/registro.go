@@ -0,0 +1,171 @@
+package operComandos
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+	TDADICC "tdas/diccionario"
+)
+
+// Valor de MaxParametros para los comandos que aceptan cualquier cantidad de parametros desde MinParametros
+const PARAMETROS_ILIMITADOS = -1
+
+// Funcion que ejecuta un comando. Recibe los parametros ya validados en cantidad, y es responsable de parsearlos
+// y de devolver el error tipado correspondiente si alguno es invalido
+type Ejecutor func(ctx *Contexto, parametros []string) error
+
+// Declaracion de un comando: su nombre, los parametros que acepta, su ayuda y la funcion que lo ejecuta
+type Comando struct {
+	Nombre string
+	// Parametros tal como se muestran en la ayuda, por ejemplo "<IP1> <IP2>". Los opcionales van entre corchetes
+	Parametros    string
+	Descripcion   string
+	MinParametros int
+	MaxParametros int
+	// Si es true el comando falla con ErrSinDatos cuando todavia no se cargo ningun log
+	RequiereDatos bool
+	// Si es true el comando falla con ErrDatasetAproximado cuando el dataset actual no guarda los conteos exactos de
+	// cada recurso
+	RequiereConteosExactos bool
+	Ejecutar               Ejecutor
+}
+
+// Registro de los comandos disponibles, que se listan en el orden en que se registraron
+type Registro struct {
+	comandos TDADICC.Diccionario[string, Comando]
+	orden    []string
+}
+
+// PRE:
+// POST: crea un registro sin comandos
+func CrearRegistro() *Registro {
+	return &Registro{comandos: TDADICC.CrearHash[string, Comando]()}
+}
+
+// PRE:
+// POST: crea un registro con todos los comandos de analisis de logs y el comando ayuda
+func CrearRegistroPredeterminado() *Registro {
+	registro := CrearRegistro()
+	RegistrarComandosAnalisis(registro)
+	registro.Registrar(comandoAyuda(registro))
+	return registro
+}
+
+// PRE: el registro debe de existir y el comando debe de tener nombre, una cantidad de parametros valida y un ejecutor
+// POST: agrega el comando al registro. Si ya hay un comando con ese nombre o la declaracion es invalida entra en panico
+func (r *Registro) Registrar(comando Comando) {
+	if r.comandos.Pertenece(comando.Nombre) {
+		panic("El comando ya esta registrado")
+	}
+	maximoValido := comando.MaxParametros == PARAMETROS_ILIMITADOS || comando.MaxParametros >= comando.MinParametros
+	if comando.Nombre == "" || comando.Ejecutar == nil || comando.MinParametros < 0 || !maximoValido {
+		panic("Declaracion de comando invalida")
+	}
+	r.comandos.Guardar(comando.Nombre, comando)
+	r.orden = append(r.orden, comando.Nombre)
+}
+
+// PRE: el registro debe de existir
+// POST: retorna el comando con el nombre indicado y true, o un comando vacio y false si no esta registrado
+func (r *Registro) Obtener(nombre string) (Comando, bool) {
+	if !r.comandos.Pertenece(nombre) {
+		return Comando{}, false
+	}
+	return r.comandos.Obtener(nombre), true
+}
+
+// PRE: el registro debe de existir
+// POST: retorna una secuencia con los comandos registrados, en el orden en que se registraron
+func (r *Registro) Comandos() iter.Seq[Comando] {
+	return func(yield func(Comando) bool) {
+		for _, nombre := range r.orden {
+			if !yield(r.comandos.Obtener(nombre)) {
+				return
+			}
+		}
+	}
+}
+
+// PRE: el registro y el contexto deben de existir. parametros contiene los parametros ingresados luego del comando
+// POST: valida la cantidad de parametros y ejecuta el comando. Si el comando no existe o falla devuelve un *ErrorComando
+// que envuelve al tipo de error (ErrComandoDesconocido, ErrParametroInvalido, ErrIPInvalida, etc.) con su detalle
+func (r *Registro) Ejecutar(ctx *Contexto, nombre string, parametros []string) error {
+	if err := r.ejecutar(ctx, nombre, parametros); err != nil {
+		return &ErrorComando{Comando: nombre, Err: err}
+	}
+	return nil
+}
+
+// PRE: el registro y el contexto deben de existir
+// POST: ejecuta el comando y devuelve el error tipado si el comando falla
+func (r *Registro) ejecutar(ctx *Contexto, nombre string, parametros []string) error {
+	comando, ok := r.Obtener(nombre)
+	if !ok {
+		return ErrComandoDesconocido
+	}
+	if err := comando.validarCantidad(parametros); err != nil {
+		return err
+	}
+	if comando.RequiereDatos && ctx.sinDatos() {
+		return ErrSinDatos
+	}
+	if comando.RequiereConteosExactos && ctx.Aproximado {
+		return errorDetallado(ErrDatasetAproximado, "%s", ctx.Nombre)
+	}
+	return comando.Ejecutar(ctx, parametros)
+}
+
+// PRE:
+// POST: retorna la forma de uso del comando: su nombre seguido de sus parametros
+func (c Comando) Uso() string {
+	return strings.TrimSpace(c.Nombre + " " + c.Parametros)
+}
+
+// PRE:
+// POST: devuelve un error de parametro invalido, con la forma de uso, si la cantidad de parametros no es la que acepta el comando
+func (c Comando) validarCantidad(parametros []string) error {
+	cantidad := len(parametros)
+	if cantidad >= c.MinParametros && (c.MaxParametros == PARAMETROS_ILIMITADOS || cantidad <= c.MaxParametros) {
+		return nil
+	}
+	var esperados string
+	switch {
+	case c.MaxParametros == PARAMETROS_ILIMITADOS:
+		esperados = fmt.Sprintf("al menos %d", c.MinParametros)
+	case c.MinParametros == c.MaxParametros:
+		esperados = fmt.Sprintf("%d", c.MinParametros)
+	default:
+		esperados = fmt.Sprintf("entre %d y %d", c.MinParametros, c.MaxParametros)
+	}
+	return errorDetallado(ErrParametroInvalido, "cantidad de parametros incorrecta, se recibieron %d y se esperan %s (uso: %s)", cantidad, esperados, c.Uso())
+}
+
+// PRE: el registro debe de existir
+// POST: retorna el comando ayuda, que lista los comandos del registro o muestra la ayuda de uno en particular
+func comandoAyuda(registro *Registro) Comando {
+	return Comando{
+		Nombre:        "ayuda",
+		Parametros:    "[comando]",
+		Descripcion:   "Lista los comandos disponibles, o muestra el uso y la descripcion del comando indicado",
+		MinParametros: 0,
+		MaxParametros: 1,
+		Ejecutar: func(ctx *Contexto, parametros []string) error {
+			if len(parametros) == 0 {
+				fmt.Fprintln(ctx.Salida, "Comandos disponibles:")
+				for comando := range registro.Comandos() {
+					fmt.Fprintf(ctx.Salida, "\t%s\n", comando.Uso())
+				}
+				fmt.Fprintln(ctx.Salida, "OK")
+				return nil
+			}
+			comando, ok := registro.Obtener(parametros[0])
+			if !ok {
+				return errorDetallado(ErrComandoDesconocido, "%q", parametros[0])
+			}
+			fmt.Fprintln(ctx.Salida, comando.Uso())
+			fmt.Fprintf(ctx.Salida, "\t%s\n", comando.Descripcion)
+			fmt.Fprintln(ctx.Salida, "OK")
+			return nil
+		},
+	}
+}
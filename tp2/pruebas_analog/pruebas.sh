@@ -13,7 +13,17 @@ for x in *.test; do
     b=${x%.test}
     printf "${b} "
     cat ${b}.test
-    ($PROGRAMA < ${b}_in > ${b}_actual_out 2> ${b}_actual_err && \
+    # El programa debe terminar con estado 1 si y solo si algun comando fallo, salvo que la prueba tenga un archivo
+    # _estado con el estado esperado (por ejemplo, 2 si los argumentos son invalidos)
+    ESPERADO=0
+    [ -s ${b}_err ] && ESPERADO=1
+    [ -f ${b}_estado ] && ESPERADO=$(cat ${b}_estado)
+    ESTADO=0
+    # Si la prueba tiene un archivo _args, su contenido se pasa como argumentos del programa
+    ARGS=""
+    [ -f ${b}_args ] && ARGS=$(cat ${b}_args)
+    $PROGRAMA $ARGS < ${b}_in > ${b}_actual_out 2> ${b}_actual_err || ESTADO=$?
+    ([ $ESTADO -eq $ESPERADO ] && \
         diff --suppress-common-lines -y -W 60 ${b}_out ${b}_actual_out && \
         diff --suppress-common-lines -y -W 60 ${b}_err ${b}_actual_err && \
         echo "OK") || { RET=$?; echo "ERROR"; }
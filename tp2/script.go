@@ -0,0 +1,191 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	operacionesComandos "tp2/operComandos"
+)
+
+const COMENTARIO = "#"
+
+var (
+	nombreVariable = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	// Nombre de variable al principio de un texto, para reconocer $NOMBRE dentro de una palabra
+	prefijoVariable = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*`)
+)
+
+// Interprete de la entrada por lotes y de los scripts: ignora las lineas vacias y los comentarios, reemplaza las
+// variables y permite incluir otros scripts
+type interprete struct {
+	registro  *operacionesComandos.Registro
+	ctx       *operacionesComandos.Contexto
+	variables map[string]string
+	// Scripts en ejecucion, del mas externo al actual, para resolver las inclusiones relativas y detectar las ciclicas
+	scripts []scriptEnEjecucion
+	exito   bool
+}
+
+type scriptEnEjecucion struct {
+	ruta     string
+	absoluta string
+}
+
+// PRE: el registro y el contexto deben de existir
+// POST: crea el interprete y registra sus comandos ejecutar_script y definir
+func crearInterprete(registro *operacionesComandos.Registro, ctx *operacionesComandos.Contexto) *interprete {
+	in := &interprete{registro: registro, ctx: ctx, variables: make(map[string]string), exito: true}
+	registro.Registrar(operacionesComandos.Comando{
+		Nombre:        "ejecutar_script",
+		Parametros:    "<file>",
+		Descripcion:   "Ejecuta los comandos del script. Las rutas relativas se resuelven desde el script que lo incluye",
+		MinParametros: 1,
+		MaxParametros: 1,
+		Ejecutar:      in.ejecutarScript,
+	})
+	registro.Registrar(operacionesComandos.Comando{
+		Nombre:        "definir",
+		Parametros:    "<VARIABLE> <valor>",
+		Descripcion:   "Define una variable, que se reemplaza en los comandos siguientes al escribir $VARIABLE o ${VARIABLE}",
+		MinParametros: 2,
+		MaxParametros: 2,
+		Ejecutar:      in.definir,
+	})
+	return in
+}
+
+// PRE: el interprete debe de existir
+// POST: ejecuta el comando de la linea, ignorando lo que sigue a una palabra que empieza con '#' y reemplazando las
+// variables en el comando y sus parametros. Si la linea no tiene comando no hace nada
+func (in *interprete) procesarLinea(linea string) error {
+	partes := strings.Fields(linea)
+	if comentario := slices.IndexFunc(partes, esComentario); comentario >= 0 {
+		partes = partes[:comentario]
+	}
+	if len(partes) == 0 {
+		return nil
+	}
+	expandidas, err := in.expandir(partes)
+	if err != nil {
+		return &operacionesComandos.ErrorComando{Comando: partes[0], Err: err}
+	}
+	return in.registro.Ejecutar(in.ctx, expandidas[0], expandidas[1:])
+}
+
+// PRE:
+// POST: retorna true si la palabra empieza un comentario
+func esComentario(palabra string) bool {
+	return strings.HasPrefix(palabra, COMENTARIO)
+}
+
+// PRE: el interprete debe de existir
+// POST: retorna las palabras con las variables reemplazadas por su valor, buscandolas primero entre las definidas y
+// luego en el entorno. "$$" se reemplaza por "$". Un $NOMBRE que no esta definido se deja tal cual, para no romper los
+// parametros que contienen '$'; solo la forma ${NOMBRE} retorna un error si la variable no esta definida
+func (in *interprete) expandir(partes []string) ([]string, error) {
+	expandidas := make([]string, len(partes))
+	for i, parte := range partes {
+		expandida, err := in.expandirPalabra(parte)
+		if err != nil {
+			return nil, err
+		}
+		expandidas[i] = expandida
+	}
+	return expandidas, nil
+}
+
+// PRE: el interprete debe de existir
+// POST: retorna la palabra con sus variables reemplazadas, segun las reglas de expandir
+func (in *interprete) expandirPalabra(palabra string) (string, error) {
+	var expandida strings.Builder
+	for i := 0; i < len(palabra); {
+		if palabra[i] != '$' || i+1 == len(palabra) {
+			expandida.WriteByte(palabra[i])
+			i++
+			continue
+		}
+		resto := palabra[i+1:]
+		if resto[0] == '$' {
+			expandida.WriteByte('$')
+			i += 2
+			continue
+		}
+		if resto[0] == '{' {
+			if fin := strings.IndexByte(resto, '}'); fin > 0 && nombreVariable.MatchString(resto[1:fin]) {
+				valor, ok := in.valorVariable(resto[1:fin])
+				if !ok {
+					return "", fmt.Errorf("%w: la variable $%s no esta definida", operacionesComandos.ErrParametroInvalido, resto[1:fin])
+				}
+				expandida.WriteString(valor)
+				i += fin + 2
+				continue
+			}
+		}
+		largo := len(prefijoVariable.FindString(resto))
+		if valor, ok := in.valorVariable(resto[:largo]); largo > 0 && ok {
+			expandida.WriteString(valor)
+			i += largo + 1
+			continue
+		}
+		expandida.WriteByte('$')
+		i++
+	}
+	return expandida.String(), nil
+}
+
+// PRE: el interprete debe de existir
+// POST: retorna el valor de la variable, buscandola primero entre las definidas y luego en el entorno, o false si no
+// esta definida
+func (in *interprete) valorVariable(nombre string) (string, bool) {
+	if valor, ok := in.variables[nombre]; ok {
+		return valor, true
+	}
+	return os.LookupEnv(nombre)
+}
+
+// PRE: el interprete debe de existir
+// POST: ejecuta los comandos del script. Los errores de sus comandos se muestran con el script y la linea donde
+// ocurrieron, y el script sigue; solo se devuelve un error si no se puede abrir o si ya se esta ejecutando
+func (in *interprete) ejecutarScript(_ *operacionesComandos.Contexto, parametros []string) error {
+	ruta := in.resolverRuta(parametros[0])
+	absoluta, err := filepath.Abs(ruta)
+	if err != nil {
+		absoluta = ruta
+	}
+	if slices.ContainsFunc(in.scripts, func(script scriptEnEjecucion) bool { return script.absoluta == absoluta }) {
+		return fmt.Errorf("%w: inclusion ciclica de %s", operacionesComandos.ErrParametroInvalido, ruta)
+	}
+	file, err := operacionesComandos.AbrirArchivo(ruta)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	in.scripts = append(in.scripts, scriptEnEjecucion{ruta: ruta, absoluta: absoluta})
+	defer func() { in.scripts = in.scripts[:len(in.scripts)-1] }()
+	in.procesar(file, ruta)
+	return nil
+}
+
+// PRE: el interprete debe de existir
+// POST: retorna la ruta del script a incluir. Las rutas relativas dentro de un script son relativas a su directorio,
+// y las de la entrada estandar al directorio actual
+func (in *interprete) resolverRuta(ruta string) string {
+	if filepath.IsAbs(ruta) || len(in.scripts) == 0 {
+		return ruta
+	}
+	return filepath.Join(filepath.Dir(in.scripts[len(in.scripts)-1].ruta), ruta)
+}
+
+// PRE: el interprete debe de existir
+// POST: define la variable con el valor indicado, reemplazando el anterior si ya estaba definida
+func (in *interprete) definir(_ *operacionesComandos.Contexto, parametros []string) error {
+	nombre, valor := parametros[0], parametros[1]
+	if !nombreVariable.MatchString(nombre) {
+		return fmt.Errorf("%w: nombre de variable invalido %q", operacionesComandos.ErrParametroInvalido, nombre)
+	}
+	in.variables[nombre] = valor
+	return nil
+}
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	operacionesComandos "tp2/operComandos"
+)
+
+const (
+	NOMBRE_PROGRAMA    = "analisisLog"
+	IP_MINIMA          = "0.0.0.0"
+	IP_MAXIMA          = "255.255.255.255"
+	ESTADO_USO_ERRONEO = 2
+)
+
+// Subcomando de uso directo: carga los logs indicados como argumentos y ejecuta sobre ellos comandos del registro,
+// sin tener que escribirlos en la entrada estandar
+type subcomando struct {
+	nombre      string
+	descripcion string
+	// Define los flags propios del subcomando y retorna la funcion que, una vez parseados, arma los comandos a ejecutar
+	// sobre los logs indicados
+	definir func(flags *flag.FlagSet) func(logs []string) [][]string
+	// Flags que se deben indicar siempre
+	obligatorios []string
+	// Pares de flags que no se pueden indicar juntos con valores distintos a los predeterminados
+	incompatibles [][2]string
+	// Si es true se muestra la salida de la carga de los logs, que incluye las alertas de DoS
+	mostrarCarga bool
+	// Si es mayor a 0, la cantidad exacta de logs que recibe, descriptos en el uso por argumentos
+	cantidadLogs int
+	argumentos   string
+	// Si es true los logs no se cargan en el contexto, sino que los comandos armados los reciben como parametros
+	sinCarga bool
+}
+
+var subcomandos = []subcomando{
+	{
+		nombre:      "top",
+		descripcion: "muestra los recursos mas visitados",
+		definir: func(flags *flag.FlagSet) func([]string) [][]string {
+			n := flags.Int("n", 10, "cantidad de recursos a mostrar")
+			aprox := flags.Bool("aprox", false, "estimar con memoria acotada en lugar de contar exacto")
+			prefijo := flags.String("prefijo", "", "mostrar solo los recursos bajo este prefijo")
+			return func([]string) [][]string {
+				if *prefijo != "" {
+					return [][]string{{"ver_mas_visitados_prefijo", *prefijo, strconv.Itoa(*n)}}
+				}
+				comando := []string{"ver_mas_visitados", strconv.Itoa(*n)}
+				if *aprox {
+					comando = append(comando, operacionesComandos.MODO_APROXIMADO)
+				}
+				return [][]string{comando}
+			}
+		},
+		// El TopK aproximado no sabe contar bajo un prefijo: solo guarda los recursos mas visitados de todo el log
+		incompatibles: [][2]string{{"aprox", "prefijo"}},
+	},
+	{
+		nombre:      "visitantes",
+		descripcion: "lista las IPs que realizaron alguna peticion dentro de un rango",
+		definir: func(flags *flag.FlagSet) func([]string) [][]string {
+			desde := flags.String("desde", IP_MINIMA, "primera IP del rango")
+			hasta := flags.String("hasta", IP_MAXIMA, "ultima IP del rango")
+			return func([]string) [][]string {
+				return [][]string{{"ver_visitantes", *desde, *hasta}}
+			}
+		},
+	},
+	{
+		nombre:       "dos",
+		descripcion:  "muestra las IPs sospechosas de DoS",
+		definir:      func(*flag.FlagSet) func([]string) [][]string { return func([]string) [][]string { return nil } },
+		mostrarCarga: true,
+	},
+	{
+		nombre:      "incidentes",
+		descripcion: "muestra los incidentes de DoS que se solapan con un instante o un rango de tiempo",
+		definir: func(flags *flag.FlagSet) func([]string) [][]string {
+			desde := flags.String("desde", "", "inicio del rango, con el formato de los logs (obligatorio)")
+			hasta := flags.String("hasta", "", "fin del rango, si se omite se consulta el instante desde")
+			return func([]string) [][]string {
+				comando := []string{"ver_incidentes", *desde}
+				if *hasta != "" {
+					comando = append(comando, *hasta)
+				}
+				return [][]string{comando}
+			}
+		},
+		obligatorios: []string{"desde"},
+	},
+	{
+		nombre:      "arbol",
+		descripcion: "muestra el arbol de recursos con sus visitas",
+		definir: func(flags *flag.FlagSet) func([]string) [][]string {
+			profundidad := flags.Int("profundidad", 1, "profundidad maxima de las rutas")
+			return func([]string) [][]string {
+				return [][]string{{"ver_arbol_recursos", strconv.Itoa(*profundidad)}}
+			}
+		},
+	},
+	{
+		nombre:      "contar",
+		descripcion: "estima la cantidad de visitantes unicos",
+		definir: func(flags *flag.FlagSet) func([]string) [][]string {
+			recurso := flags.String("recurso", "", "contar solo los visitantes de este recurso")
+			return func([]string) [][]string {
+				comando := []string{"contar_visitantes_aprox"}
+				if *recurso != "" {
+					comando = append(comando, *recurso)
+				}
+				return [][]string{comando}
+			}
+		},
+	},
+	{
+		nombre:      "visto",
+		descripcion: "responde si una IP realizo alguna peticion",
+		definir: func(flags *flag.FlagSet) func([]string) [][]string {
+			ip := flags.String("ip", "", "IP a consultar (obligatorio)")
+			return func([]string) [][]string {
+				return [][]string{{"ya_visto", *ip}}
+			}
+		},
+		obligatorios: []string{"ip"},
+	},
+	{
+		nombre:      "comparar",
+		descripcion: "compara sus IPs, las visitas a sus recursos y sus sospechosos de DoS",
+		definir: func(flags *flag.FlagSet) func([]string) [][]string {
+			umbral := flags.Float64("umbral", operacionesComandos.UMBRAL_CAMBIO_PREDETERMINADO, "cambio porcentual de visitas a partir del cual se muestra un recurso")
+			json := flags.Bool("json", false, "mostrar la comparacion como JSON")
+			return func(logs []string) [][]string {
+				formato := operacionesComandos.FORMATO_TEXTO
+				if *json {
+					formato = operacionesComandos.FORMATO_JSON
+				}
+				return [][]string{{"comparar_archivos", logs[0], logs[1], strconv.FormatFloat(*umbral, 'g', -1, 64), formato}}
+			}
+		},
+		cantidadLogs: 2,
+		argumentos:   "<antes> <despues>",
+		sinCarga:     true,
+	},
+}
+
+// PRE: args debe de contener los argumentos del programa, sin su nombre, empezando por el subcomando
+// POST: ejecuta el subcomando y retorna false si fallo algun comando. Si el subcomando no existe o sus argumentos son
+// invalidos muestra el uso y termina el programa con estado ESTADO_USO_ERRONEO
+func ejecutarSubcomando(registro *operacionesComandos.Registro, ctx *operacionesComandos.Contexto, args []string) bool {
+	nombre := args[0]
+	if nombre == "-h" || nombre == "-help" || nombre == "--help" {
+		mostrarUso(os.Stdout)
+		return true
+	}
+	sub, ok := buscarSubcomando(nombre)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Subcomando desconocido: %s\n", nombre)
+		mostrarUso(os.Stderr)
+		os.Exit(ESTADO_USO_ERRONEO)
+	}
+
+	flags := flag.NewFlagSet(NOMBRE_PROGRAMA+" "+sub.nombre, flag.ExitOnError)
+	redes := flags.String("redes", "", "archivo de redes CIDR con las que etiquetar las IPs")
+	estricto := new(bool)
+	if !sub.sinCarga {
+		estricto = flags.Bool("estricto", false, "fallar si algun log tiene lineas invalidas")
+	}
+	armar := sub.definir(flags)
+	argumentos := "<log> [log ...]"
+	if sub.argumentos != "" {
+		argumentos = sub.argumentos
+	}
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Uso: %s %s [flags] %s\n", NOMBRE_PROGRAMA, sub.nombre, argumentos)
+		if sub.sinCarga {
+			fmt.Fprintf(flags.Output(), "Lee los logs y %s.\n", sub.descripcion)
+		} else {
+			fmt.Fprintf(flags.Output(), "Carga los logs y %s.\n", sub.descripcion)
+		}
+		flags.PrintDefaults()
+	}
+	flags.Parse(args[1:])
+	logs := flags.Args()
+	if len(logs) == 0 {
+		usoErroneo(flags, "Se debe indicar al menos un archivo de log")
+	}
+	if sub.cantidadLogs > 0 && len(logs) != sub.cantidadLogs {
+		usoErroneo(flags, fmt.Sprintf("Se deben indicar %d archivos de log", sub.cantidadLogs))
+	}
+	indicados, cambiados := make(map[string]bool), make(map[string]bool)
+	flags.Visit(func(f *flag.Flag) {
+		indicados[f.Name] = true
+		cambiados[f.Name] = f.Value.String() != f.DefValue
+	})
+	for _, obligatorio := range sub.obligatorios {
+		if !indicados[obligatorio] {
+			usoErroneo(flags, fmt.Sprintf("Falta el flag obligatorio -%s", obligatorio))
+		}
+	}
+	for _, par := range sub.incompatibles {
+		if cambiados[par[0]] && cambiados[par[1]] {
+			usoErroneo(flags, fmt.Sprintf("Los flags -%s y -%s no se pueden indicar juntos", par[0], par[1]))
+		}
+	}
+
+	cargados := logs
+	if sub.sinCarga {
+		// Los logs no se cargan en el contexto, pero las redes se cargan igual para etiquetar las IPs
+		cargados = nil
+	}
+	if !ejecutarCarga(registro, ctx, *redes, *estricto, cargados, sub.mostrarCarga) {
+		return false
+	}
+	exito := true
+	for _, comando := range armar(logs) {
+		exito = ejecutarComando(registro, ctx, comando) && exito
+	}
+	return exito
+}
+
+// PRE: el registro y el contexto deben de existir
+// POST: carga las redes, si se indicaron, y los logs, si hay, fusionandolos en orden cronologico si son varios. La
+// salida de la carga solo se muestra si mostrar es true. Retorna false si algo fallo
+func ejecutarCarga(registro *operacionesComandos.Registro, ctx *operacionesComandos.Contexto, redes string, estricto bool, logs []string, mostrar bool) bool {
+	salida := ctx.Salida
+	if !mostrar {
+		ctx.Salida = io.Discard
+	}
+	defer func() { ctx.Salida = salida }()
+
+	if redes != "" && !ejecutarComando(registro, ctx, []string{"cargar_redes", redes}) {
+		return false
+	}
+	if len(logs) == 0 {
+		return true
+	}
+	comando := append([]string{"agregar_archivos_fusionados"}, logs...)
+	if estricto {
+		comando = append(comando, operacionesComandos.MODO_ESTRICTO)
+	}
+	return ejecutarComando(registro, ctx, comando)
+}
+
+// PRE: flags debe de ser el conjunto de flags del subcomando
+// POST: muestra el motivo y el uso del subcomando y termina el programa con estado ESTADO_USO_ERRONEO
+func usoErroneo(flags *flag.FlagSet, motivo string) {
+	fmt.Fprintln(flags.Output(), motivo)
+	flags.Usage()
+	os.Exit(ESTADO_USO_ERRONEO)
+}
+
+// PRE:
+// POST: retorna el subcomando con el nombre indicado y true, o false si no existe
+func buscarSubcomando(nombre string) (subcomando, bool) {
+	for _, sub := range subcomandos {
+		if sub.nombre == nombre {
+			return sub, true
+		}
+	}
+	return subcomando{}, false
+}
+
+// PRE:
+// POST: muestra los modos de uso del programa y los subcomandos disponibles
+func mostrarUso(salida io.Writer) {
+	fmt.Fprintf(salida, "Uso: %s                         lee los comandos de la entrada estandar\n", NOMBRE_PROGRAMA)
+	fmt.Fprintf(salida, "     %s <subcomando> [flags] <log> [log ...]\n\n", NOMBRE_PROGRAMA)
+	fmt.Fprintln(salida, "Subcomandos:")
+	for _, sub := range subcomandos {
+		fmt.Fprintf(salida, "\t%-12s%s\n", sub.nombre, sub.descripcion)
+	}
+	fmt.Fprintf(salida, "\nUse %s <subcomando> -h para ver los flags de cada uno.\n", NOMBRE_PROGRAMA)
+}